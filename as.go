@@ -0,0 +1,155 @@
+package errors
+
+// Typed accessors for each failure type. Unlike the Is* predicates, they
+// traverse the wrap chain and return the failure itself, so callers can
+// extract violations without manual type assertions.
+
+// AsBadRequest returns the first BadRequest in the chain of `err`
+func AsBadRequest(err error) (*BadRequest, bool) {
+	var f *BadRequest
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsPreconditionFailure returns the first PreconditionFailure in the chain
+// of `err`
+func AsPreconditionFailure(err error) (*PreconditionFailure, bool) {
+	var f *PreconditionFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsConflictFailure returns the first ConflictFailure in the chain of `err`
+func AsConflictFailure(err error) (*ConflictFailure, bool) {
+	var f *ConflictFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsQuotaFailure returns the first QuotaFailure in the chain of `err`
+func AsQuotaFailure(err error) (*QuotaFailure, bool) {
+	var f *QuotaFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsOutOfRangeFailure returns the first OutOfRangeFailure in the chain
+// of `err`
+func AsOutOfRangeFailure(err error) (*OutOfRangeFailure, bool) {
+	var f *OutOfRangeFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsAvailabilityFailure returns the first AvailabilityFailure in the chain
+// of `err`
+func AsAvailabilityFailure(err error) (*AvailabilityFailure, bool) {
+	var f *AvailabilityFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsMissingFailure returns the first MissingFailure in the chain of `err`
+func AsMissingFailure(err error) (*MissingFailure, bool) {
+	var f *MissingFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsPermissionFailure returns the first PermissionFailure in the chain
+// of `err`
+func AsPermissionFailure(err error) (*PermissionFailure, bool) {
+	var f *PermissionFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsAuthenticationFailure returns the first AuthenticationFailure in the
+// chain of `err`
+func AsAuthenticationFailure(err error) (*AuthenticationFailure, bool) {
+	var f *AuthenticationFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsDataLossFailure returns the first DataLossFailure in the chain of `err`
+func AsDataLossFailure(err error) (*DataLossFailure, bool) {
+	var f *DataLossFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsInternalFailure returns the first InternalFailure in the chain of `err`
+func AsInternalFailure(err error) (*InternalFailure, bool) {
+	var f *InternalFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsTimeoutFailure returns the first TimeoutFailure in the chain of `err`
+func AsTimeoutFailure(err error) (*TimeoutFailure, bool) {
+	var f *TimeoutFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsCancelledFailure returns the first CancelledFailure in the chain of `err`
+func AsCancelledFailure(err error) (*CancelledFailure, bool) {
+	var f *CancelledFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsExistsFailure returns the first ExistsFailure in the chain of `err`
+func AsExistsFailure(err error) (*ExistsFailure, bool) {
+	var f *ExistsFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsNotImplementedFailure returns the first NotImplementedFailure in the
+// chain of `err`
+func AsNotImplementedFailure(err error) (*NotImplementedFailure, bool) {
+	var f *NotImplementedFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+// AsMultiFailure returns the first MultiFailure in the chain of `err`
+func AsMultiFailure(err error) (*MultiFailure, bool) {
+	var f *MultiFailure
+	if As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
@@ -0,0 +1,130 @@
+// Package budget tracks per-code error counts and rates over sliding
+// windows, so callers can trip load-shedding or alerting when failure rates
+// spike. Feed it from the creation hook or a transport middleware:
+//
+//	errors.RegisterHook(budget.Record)
+//
+// and query it where the decision is made:
+//
+//	if budget.Rate(errors.CodeUnavailable, time.Minute) > 5 {
+//		// shed load
+//	}
+package budget
+
+import (
+	"sync"
+	"time"
+
+	"github.com/deixis/errors"
+)
+
+// Now returns the current time. It is a variable, so it can be stubbed
+// during tests.
+var Now = time.Now
+
+// Tracker records per-code error counts in fixed-resolution buckets and
+// answers sliding-window queries over them. The zero value is not usable;
+// use NewTracker.
+type Tracker struct {
+	mu        sync.Mutex
+	res       time.Duration
+	retention time.Duration
+	buckets   map[int64]map[errors.Code]uint64
+}
+
+// NewTracker returns a tracker that retains counts for the given duration
+// in buckets of the given resolution. Queries over windows longer than the
+// retention only see the retained part.
+func NewTracker(retention, resolution time.Duration) *Tracker {
+	if resolution <= 0 {
+		resolution = time.Second
+	}
+	if retention < resolution {
+		retention = resolution
+	}
+	return &Tracker{
+		res:       resolution,
+		retention: retention,
+		buckets:   map[int64]map[errors.Code]uint64{},
+	}
+}
+
+// Record counts err under its code (see errors.CodeOf). A nil error is
+// ignored. Record matches the errors.Hook signature, so a tracker can be
+// fed directly from the creation hook.
+func (t *Tracker) Record(err error) {
+	if err == nil {
+		return
+	}
+	code := errors.CodeOf(err)
+	now := Now()
+	key := now.Truncate(t.res).UnixNano()
+
+	t.mu.Lock()
+	b, ok := t.buckets[key]
+	if !ok {
+		b = map[errors.Code]uint64{}
+		t.buckets[key] = b
+		t.prune(now)
+	}
+	b[code]++
+	t.mu.Unlock()
+}
+
+// Count returns the number of errors recorded with the given code over the
+// trailing window
+func (t *Tracker) Count(code errors.Code, window time.Duration) uint64 {
+	cutoff := Now().Add(-window).UnixNano()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var n uint64
+	for key, b := range t.buckets {
+		if key+int64(t.res) <= cutoff {
+			continue
+		}
+		n += b[code]
+	}
+	return n
+}
+
+// Rate returns the number of errors per second recorded with the given code
+// over the trailing window
+func (t *Tracker) Rate(code errors.Code, window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+	return float64(t.Count(code, window)) / window.Seconds()
+}
+
+// prune drops buckets that fell out of the retention period. It must be
+// called with the mutex held.
+func (t *Tracker) prune(now time.Time) {
+	cutoff := now.Add(-t.retention).UnixNano()
+	for key := range t.buckets {
+		if key+int64(t.res) <= cutoff {
+			delete(t.buckets, key)
+		}
+	}
+}
+
+// The default tracker retains ten minutes of counts at one-second
+// resolution, which covers the windows load-shedding decisions usually
+// operate on.
+var std = NewTracker(10*time.Minute, time.Second)
+
+// Record counts err on the default tracker
+func Record(err error) { std.Record(err) }
+
+// Count returns the number of errors recorded with the given code over the
+// trailing window on the default tracker
+func Count(code errors.Code, window time.Duration) uint64 {
+	return std.Count(code, window)
+}
+
+// Rate returns the number of errors per second recorded with the given code
+// over the trailing window on the default tracker
+func Rate(code errors.Code, window time.Duration) float64 {
+	return std.Rate(code, window)
+}
@@ -0,0 +1,82 @@
+package budget_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/budget"
+)
+
+func TestTracker_Count(t *testing.T) {
+	now := time.Date(2020, 2, 10, 21, 42, 0, 0, time.UTC)
+	budget.Now = func() time.Time { return now }
+	defer func() { budget.Now = time.Now }()
+
+	tr := budget.NewTracker(10*time.Minute, time.Second)
+	for i := 0; i < 3; i++ {
+		tr.Record(errors.Unavailable(time.Second))
+	}
+	tr.Record(errors.NotFound)
+	tr.Record(nil)
+
+	if n := tr.Count(errors.CodeUnavailable, time.Minute); n != 3 {
+		t.Errorf("expect 3 unavailable errors, but got %d", n)
+	}
+	if n := tr.Count(errors.CodeNotFound, time.Minute); n != 1 {
+		t.Errorf("expect 1 not found error, but got %d", n)
+	}
+	if n := tr.Count(errors.CodeAborted, time.Minute); n != 0 {
+		t.Errorf("expect 0 aborted errors, but got %d", n)
+	}
+}
+
+func TestTracker_SlidingWindow(t *testing.T) {
+	now := time.Date(2020, 2, 10, 21, 42, 0, 0, time.UTC)
+	budget.Now = func() time.Time { return now }
+	defer func() { budget.Now = time.Now }()
+
+	tr := budget.NewTracker(10*time.Minute, time.Second)
+	tr.Record(errors.Unavailable(time.Second))
+
+	now = now.Add(30 * time.Second)
+	tr.Record(errors.Unavailable(time.Second))
+
+	if n := tr.Count(errors.CodeUnavailable, time.Minute); n != 2 {
+		t.Errorf("expect 2 errors within the minute, but got %d", n)
+	}
+	if n := tr.Count(errors.CodeUnavailable, 10*time.Second); n != 1 {
+		t.Errorf("expect 1 error within 10s, but got %d", n)
+	}
+}
+
+func TestTracker_Rate(t *testing.T) {
+	now := time.Date(2020, 2, 10, 21, 42, 0, 0, time.UTC)
+	budget.Now = func() time.Time { return now }
+	defer func() { budget.Now = time.Now }()
+
+	tr := budget.NewTracker(10*time.Minute, time.Second)
+	for i := 0; i < 60; i++ {
+		tr.Record(errors.Unavailable(time.Second))
+	}
+
+	if rate := tr.Rate(errors.CodeUnavailable, time.Minute); rate != 1 {
+		t.Errorf("expect a rate of 1/s, but got %f", rate)
+	}
+}
+
+func TestTracker_Retention(t *testing.T) {
+	now := time.Date(2020, 2, 10, 21, 42, 0, 0, time.UTC)
+	budget.Now = func() time.Time { return now }
+	defer func() { budget.Now = time.Now }()
+
+	tr := budget.NewTracker(time.Minute, time.Second)
+	tr.Record(errors.Unavailable(time.Second))
+
+	now = now.Add(2 * time.Minute)
+	tr.Record(errors.Unavailable(time.Second))
+
+	if n := tr.Count(errors.CodeUnavailable, time.Hour); n != 1 {
+		t.Errorf("expect the expired bucket to be pruned, but got %d", n)
+	}
+}
@@ -0,0 +1,157 @@
+package errors
+
+// Clone returns a deep copy of `err`, including violation slices and
+// attached details, so middleware can safely mutate (e.g. redact) the copy
+// without racing the original stored elsewhere.
+//
+// Errors of unknown types cannot be copied and are returned as-is, as are
+// the causes wrapped by a failure.
+func Clone(err error) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *withDetails:
+		details := make([]interface{}, len(e.details))
+		for i := range e.details {
+			details[i] = cloneDetail(e.details[i])
+		}
+		return &withDetails{Clone(e.error), details}
+	case *MultiFailure:
+		errs := make([]error, len(e.Errors))
+		for i := range e.Errors {
+			errs[i] = Clone(e.Errors[i])
+		}
+		return &MultiFailure{Errors: errs}
+	case *BadRequest:
+		violations := make([]*FieldViolation, len(e.Violations))
+		for i, v := range e.Violations {
+			c := *v
+			c.Locales = cloneLocales(v.Locales)
+			violations[i] = &c
+		}
+		return &BadRequest{error: e.error, OccurredAt: e.OccurredAt, Violations: violations}
+	case *PreconditionFailure:
+		violations := make([]*PreconditionViolation, len(e.Violations))
+		for i, v := range e.Violations {
+			c := *v
+			c.Locales = cloneLocales(v.Locales)
+			violations[i] = &c
+		}
+		return &PreconditionFailure{error: e.error, OccurredAt: e.OccurredAt, Violations: violations}
+	case *ConflictFailure:
+		violations := make([]*ConflictViolation, len(e.Violations))
+		for i, v := range e.Violations {
+			c := *v
+			violations[i] = &c
+		}
+		return &ConflictFailure{error: e.error, OccurredAt: e.OccurredAt, Violations: violations}
+	case *QuotaFailure:
+		violations := make([]*QuotaViolation, len(e.Violations))
+		for i, v := range e.Violations {
+			c := *v
+			violations[i] = &c
+		}
+		return &QuotaFailure{error: e.error, OccurredAt: e.OccurredAt, Violations: violations}
+	case *OutOfRangeFailure:
+		violations := make([]*RangeViolation, len(e.Violations))
+		for i, v := range e.Violations {
+			c := *v
+			violations[i] = &c
+		}
+		return &OutOfRangeFailure{error: e.error, OccurredAt: e.OccurredAt, Violations: violations}
+	case *AvailabilityFailure:
+		c := *e
+		return &c
+	case *MissingFailure:
+		c := *e
+		return &c
+	case *PermissionFailure:
+		c := *e
+		return &c
+	case *AuthenticationFailure:
+		c := *e
+		return &c
+	case *TimeoutFailure:
+		c := *e
+		return &c
+	case *CancelledFailure:
+		c := *e
+		return &c
+	case *NotImplementedFailure:
+		c := *e
+		return &c
+	case *ExistsFailure:
+		c := *e
+		return &c
+	case *DataLossFailure:
+		c := *e
+		if e.DebugInfo != nil {
+			d := *e.DebugInfo
+			d.StackEntries = append([]string(nil), e.DebugInfo.StackEntries...)
+			c.DebugInfo = &d
+		}
+		return &c
+	case *InternalFailure:
+		c := *e
+		if e.DebugInfo != nil {
+			d := *e.DebugInfo
+			d.StackEntries = append([]string(nil), e.DebugInfo.StackEntries...)
+			c.DebugInfo = &d
+		}
+		return &c
+	default:
+		return err
+	}
+}
+
+// cloneLocales copies the per-locale variants of a violation description,
+// so the clone and the original do not share the map
+func cloneLocales(s LocalisedString) LocalisedString {
+	if s == nil {
+		return nil
+	}
+	c := make(LocalisedString, len(s))
+	for k, v := range s {
+		c[k] = v
+	}
+	return c
+}
+
+// cloneDetail deep-copies the detail types owned by this package. Custom
+// detail types are returned as-is.
+func cloneDetail(detail interface{}) interface{} {
+	switch d := detail.(type) {
+	case *RetryInfo:
+		c := *d
+		return &c
+	case *LocalisedMessage:
+		c := *d
+		return &c
+	case *Correlation:
+		c := *d
+		return &c
+	case *userMessage:
+		c := *d
+		return &c
+	case *DebugInfo:
+		c := *d
+		c.StackEntries = append([]string(nil), d.StackEntries...)
+		return &c
+	case *Help:
+		c := *d
+		c.Links = make([]*HelpLink, len(d.Links))
+		for i, l := range d.Links {
+			lc := *l
+			c.Links[i] = &lc
+		}
+		return &c
+	case Metadata:
+		c := make(Metadata, len(d))
+		for k, v := range d {
+			c[k] = v
+		}
+		return c
+	default:
+		return detail
+	}
+}
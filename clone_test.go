@@ -0,0 +1,134 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestClone_Violations(t *testing.T) {
+	original, _ := errors.AsBadRequest(errors.Bad(
+		&errors.FieldViolation{Field: "email", Description: "missing"},
+	))
+
+	clone, ok := errors.AsBadRequest(errors.Clone(original))
+	if !ok {
+		t.Fatalf("expect a bad request, but got %v", clone)
+	}
+	clone.Violations[0].Description = "redacted"
+	clone.Violations = append(clone.Violations, &errors.FieldViolation{Field: "name"})
+
+	if original.Violations[0].Description != "missing" {
+		t.Errorf("expect the original violation to be untouched, but got %s",
+			original.Violations[0].Description,
+		)
+	}
+	if len(original.Violations) != 1 {
+		t.Errorf("expect the original to keep 1 violation, but got %d", len(original.Violations))
+	}
+}
+
+func TestClone_Details(t *testing.T) {
+	original := errors.WithDetails(errors.New("boom"),
+		&errors.Correlation{ID: "req-1"},
+		errors.Metadata{"service": "billing"},
+	)
+
+	clone := errors.Clone(original)
+	for _, d := range errors.Details(clone) {
+		switch d := d.(type) {
+		case *errors.Correlation:
+			d.ID = "redacted"
+		case errors.Metadata:
+			d["service"] = "redacted"
+		}
+	}
+
+	if id := errors.CorrelationID(original); id != "req-1" {
+		t.Errorf("expect the original correlation to be untouched, but got %s", id)
+	}
+	if meta := errors.Meta(original); meta["service"] != "billing" {
+		t.Errorf("expect the original metadata to be untouched, but got %s", meta["service"])
+	}
+}
+
+func TestClone_Locales(t *testing.T) {
+	original, _ := errors.AsBadRequest(errors.Bad(
+		&errors.FieldViolation{
+			Field:       "email",
+			Description: "missing",
+			Locales: errors.LocalisedString{
+				"en": "missing",
+				"fr": "manquant",
+			},
+		},
+	))
+
+	clone, _ := errors.AsBadRequest(errors.Clone(original))
+	clone.Violations[0].Locales["fr"] = "redacted"
+	clone.Violations[0].Locales["de"] = "fehlt"
+
+	if got := original.Violations[0].Locales["fr"]; got != "manquant" {
+		t.Errorf("expect the original locales to be untouched, but got %q", got)
+	}
+	if _, ok := original.Violations[0].Locales["de"]; ok {
+		t.Errorf("expect the original to not gain locales, but got %v",
+			original.Violations[0].Locales,
+		)
+	}
+}
+
+func TestClone_MultiFailure(t *testing.T) {
+	original, _ := errors.AsMultiFailure(errors.Join(
+		errors.Bad(&errors.FieldViolation{Field: "email", Description: "missing"}),
+		errors.Aborted(),
+	))
+
+	clone, ok := errors.AsMultiFailure(errors.Clone(original))
+	if !ok {
+		t.Fatalf("expect a multi failure, but got %v", clone)
+	}
+	member, _ := errors.AsBadRequest(clone.Errors[0])
+	member.Violations[0].Description = "redacted"
+
+	originalMember, _ := errors.AsBadRequest(original.Errors[0])
+	if originalMember.Violations[0].Description != "missing" {
+		t.Errorf("expect the original member to be untouched, but got %s",
+			originalMember.Violations[0].Description,
+		)
+	}
+}
+
+func TestClone_DebugInfo(t *testing.T) {
+	original := &errors.DataLossFailure{
+		DebugInfo: &errors.DebugInfo{
+			StackEntries: []string{"main.go:42"},
+			Detail:       "checksum mismatch",
+		},
+	}
+
+	clone := errors.Clone(original).(*errors.DataLossFailure)
+	clone.DebugInfo.StackEntries[0] = "redacted"
+	clone.DebugInfo.Detail = "redacted"
+
+	if original.DebugInfo.StackEntries[0] != "main.go:42" {
+		t.Errorf("expect the original stack to be untouched, but got %s",
+			original.DebugInfo.StackEntries[0],
+		)
+	}
+	if original.DebugInfo.Detail != "checksum mismatch" {
+		t.Errorf("expect the original detail to be untouched, but got %s",
+			original.DebugInfo.Detail,
+		)
+	}
+}
+
+func TestClone_Unknown(t *testing.T) {
+	err := errors.New("boom")
+	if clone := errors.Clone(err); clone != err {
+		t.Errorf("expect unknown types to be returned as-is, but got %v", clone)
+	}
+	if clone := errors.Clone(nil); clone != nil {
+		t.Errorf("expect nil, but got %v", clone)
+	}
+}
@@ -0,0 +1,150 @@
+package errors
+
+import "context"
+
+// Code is the canonical category of an error. It mirrors the gRPC canonical
+// error codes, so it maps losslessly onto the wire representations used by
+// grpcerrors and httperrors.
+type Code int
+
+const (
+	// CodeOK means no error occurred.
+	CodeOK Code = 0
+	// CodeCancelled means the operation was cancelled, typically by the caller.
+	CodeCancelled Code = 1
+	// CodeUnknown is returned for errors that carry no category.
+	CodeUnknown Code = 2
+	// CodeInvalidArgument means the client specified an invalid argument.
+	CodeInvalidArgument Code = 3
+	// CodeDeadlineExceeded means the deadline expired before the operation
+	// could complete.
+	CodeDeadlineExceeded Code = 4
+	// CodeNotFound means some requested entity was not found.
+	CodeNotFound Code = 5
+	// CodeAlreadyExists means the entity a client attempted to create
+	// already exists.
+	CodeAlreadyExists Code = 6
+	// CodePermissionDenied means the caller does not have permission to
+	// execute the specified operation.
+	CodePermissionDenied Code = 7
+	// CodeResourceExhausted means some resource has been exhausted.
+	CodeResourceExhausted Code = 8
+	// CodeFailedPrecondition means the operation was rejected because the
+	// system is not in a state required for the operation's execution.
+	CodeFailedPrecondition Code = 9
+	// CodeAborted means the operation was aborted, typically due to a
+	// concurrency issue.
+	CodeAborted Code = 10
+	// CodeOutOfRange means the operation was attempted past the valid range.
+	CodeOutOfRange Code = 11
+	// CodeUnimplemented means the operation is not implemented or is not
+	// supported.
+	CodeUnimplemented Code = 12
+	// CodeInternal means some invariant expected by the underlying system
+	// has been broken.
+	CodeInternal Code = 13
+	// CodeUnavailable means the service is currently unavailable.
+	CodeUnavailable Code = 14
+	// CodeDataLoss means unrecoverable data loss or corruption.
+	CodeDataLoss Code = 15
+	// CodeUnauthenticated means the request does not have valid
+	// authentication credentials for the operation.
+	CodeUnauthenticated Code = 16
+)
+
+func (c Code) String() string {
+	switch c {
+	case CodeOK:
+		return "ok"
+	case CodeCancelled:
+		return "cancelled"
+	case CodeUnknown:
+		return "unknown"
+	case CodeInvalidArgument:
+		return "invalid argument"
+	case CodeDeadlineExceeded:
+		return "deadline exceeded"
+	case CodeNotFound:
+		return "not found"
+	case CodeAlreadyExists:
+		return "already exists"
+	case CodePermissionDenied:
+		return "permission denied"
+	case CodeResourceExhausted:
+		return "resource exhausted"
+	case CodeFailedPrecondition:
+		return "failed precondition"
+	case CodeAborted:
+		return "aborted"
+	case CodeOutOfRange:
+		return "out of range"
+	case CodeUnimplemented:
+		return "unimplemented"
+	case CodeInternal:
+		return "internal"
+	case CodeUnavailable:
+		return "unavailable"
+	case CodeDataLoss:
+		return "data loss"
+	case CodeUnauthenticated:
+		return "unauthenticated"
+	default:
+		return "unknown"
+	}
+}
+
+// CodeOf walks the wrap chain of `err` and returns its canonical code.
+// It returns CodeOK when err is nil, and CodeUnknown when no error in the
+// chain carries a category.
+func CodeOf(err error) Code {
+	if err == nil {
+		return CodeOK
+	}
+
+	for e := err; e != nil; e = Unwrap(e) {
+		switch e {
+		case context.Canceled:
+			return CodeCancelled
+		case context.DeadlineExceeded:
+			return CodeDeadlineExceeded
+		}
+
+		switch e := e.(type) {
+		case *MultiFailure:
+			if len(e.Errors) > 0 {
+				return CodeOf(e.Errors[0])
+			}
+		case *CancelledFailure:
+			return CodeCancelled
+		case *TimeoutFailure:
+			return CodeDeadlineExceeded
+		case *BadRequest:
+			return CodeInvalidArgument
+		case *MissingFailure:
+			return CodeNotFound
+		case *PermissionFailure:
+			return CodePermissionDenied
+		case *QuotaFailure:
+			return CodeResourceExhausted
+		case *PreconditionFailure:
+			return CodeFailedPrecondition
+		case *ConflictFailure:
+			return CodeAborted
+		case *ExistsFailure:
+			return CodeAlreadyExists
+		case *OutOfRangeFailure:
+			return CodeOutOfRange
+		case *AvailabilityFailure:
+			return CodeUnavailable
+		case *DataLossFailure:
+			return CodeDataLoss
+		case *InternalFailure:
+			return CodeInternal
+		case *AuthenticationFailure:
+			return CodeUnauthenticated
+		case *NotImplementedFailure:
+			return CodeUnimplemented
+		}
+	}
+	return CodeUnknown
+}
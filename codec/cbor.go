@@ -0,0 +1,26 @@
+package codec
+
+import (
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborCodec encodes errors with the CBOR format (RFC 7049).
+type cborCodec struct{}
+
+func (cborCodec) Name() string { return "cbor" }
+
+func (cborCodec) Marshal(err error) ([]byte, error) {
+	env, packErr := packEnvelope(err)
+	if packErr != nil {
+		return nil, packErr
+	}
+	return cbor.Marshal(env)
+}
+
+func (cborCodec) Unmarshal(data []byte) (error, error) {
+	env := &envelope{}
+	if err := cbor.Unmarshal(data, env); err != nil {
+		return nil, err
+	}
+	return unpackEnvelope(env)
+}
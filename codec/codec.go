@@ -0,0 +1,97 @@
+// Package codec serialises errors for transport across non-gRPC boundaries
+// (e.g. message queues or the spine shipments gob encoder) without losing
+// their type. All codecs carry the self-describing envelope produced by
+// errors.MarshalJSON, so any failure survives the round trip.
+package codec
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/deixis/errors"
+)
+
+// Codec encodes and decodes errors to and from a wire format.
+type Codec interface {
+	// Name returns the unique name of the codec, e.g. "gob".
+	Name() string
+	// Marshal encodes `err` into the codec wire format.
+	Marshal(err error) ([]byte, error)
+	// Unmarshal decodes an error encoded with Marshal.
+	Unmarshal(data []byte) (error, error)
+}
+
+var registry = struct {
+	mu     sync.RWMutex
+	codecs map[string]Codec
+}{
+	codecs: map[string]Codec{},
+}
+
+// Register declares a codec. It is expected to be called at init time and
+// panics when a codec has already been registered under the same name.
+func Register(c Codec) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.codecs[c.Name()]; ok {
+		panic(fmt.Sprintf("codec: codec %q registered twice", c.Name()))
+	}
+	registry.codecs[c.Name()] = c
+}
+
+// Lookup returns the codec registered under the given name.
+func Lookup(name string) (Codec, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	c, ok := registry.codecs[name]
+	return c, ok
+}
+
+func init() {
+	Register(gobCodec{})
+	Register(msgpackCodec{})
+	Register(cborCodec{})
+
+	// Register the failure types with gob, so errors shipped as interface
+	// values (e.g. in spine shipments) can be decoded without extra plumbing
+	gob.Register(&errors.MissingFailure{})
+	gob.Register(&errors.PermissionFailure{})
+	gob.Register(&errors.AuthenticationFailure{})
+	gob.Register(&errors.BadRequest{})
+	gob.Register(&errors.PreconditionFailure{})
+	gob.Register(&errors.ConflictFailure{})
+	gob.Register(&errors.QuotaFailure{})
+	gob.Register(&errors.AvailabilityFailure{})
+	gob.Register(&errors.DataLossFailure{})
+	gob.Register(&errors.InternalFailure{})
+	gob.Register(&errors.OutOfRangeFailure{})
+	gob.Register(&errors.TimeoutFailure{})
+	gob.Register(&errors.CancelledFailure{})
+	gob.Register(&errors.ExistsFailure{})
+	gob.Register(&errors.NotImplementedFailure{})
+	gob.Register(&errors.MultiFailure{})
+}
+
+// envelope transports the canonical JSON representation of an error. The
+// codecs encode the envelope rather than the failure types directly, which
+// keeps the wire formats self-describing and spares every codec its own
+// type registration.
+type envelope struct {
+	Error json.RawMessage `msgpack:"error" cbor:"error"`
+}
+
+func packEnvelope(err error) (*envelope, error) {
+	data, marshalErr := errors.MarshalJSON(err)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return &envelope{Error: data}, nil
+}
+
+func unpackEnvelope(env *envelope) (error, error) {
+	return errors.UnmarshalJSON(env.Error)
+}
@@ -0,0 +1,65 @@
+package codec_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/codec"
+)
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	failures := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "bad request",
+			err: errors.Bad(&errors.FieldViolation{
+				Field:       "email",
+				Description: "invalid email address",
+			}),
+		},
+		{
+			name: "unavailable",
+			err:  errors.Unavailable(30 * time.Second),
+		},
+		{
+			name: "not found sentinel",
+			err:  errors.NotFound,
+		},
+		{
+			name: "opaque",
+			err:  errors.New("something went wrong"),
+		},
+	}
+
+	for _, name := range []string{"gob", "msgpack", "cbor"} {
+		c, ok := codec.Lookup(name)
+		if !ok {
+			t.Fatalf("expect codec %q to be registered", name)
+		}
+
+		for _, failure := range failures {
+			t.Run(name+"/"+failure.name, func(t *testing.T) {
+				data, err := c.Marshal(failure.err)
+				if err != nil {
+					t.Fatalf("expect Marshal to succeed, but got error %v", err)
+				}
+				got, err := c.Unmarshal(data)
+				if err != nil {
+					t.Fatalf("expect Unmarshal to succeed, but got error %v", err)
+				}
+				if !errors.Match(got, failure.err) {
+					t.Errorf("expect errors to match after round trip, but got %v", got)
+				}
+			})
+		}
+	}
+}
+
+func TestLookup_Unknown(t *testing.T) {
+	if _, ok := codec.Lookup("bson"); ok {
+		t.Error("expect Lookup to fail for an unregistered codec")
+	}
+}
@@ -0,0 +1,31 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// gobCodec encodes errors with the standard library gob format.
+type gobCodec struct{}
+
+func (gobCodec) Name() string { return "gob" }
+
+func (gobCodec) Marshal(err error) ([]byte, error) {
+	env, packErr := packEnvelope(err)
+	if packErr != nil {
+		return nil, packErr
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(env); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte) (error, error) {
+	env := &envelope{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(env); err != nil {
+		return nil, err
+	}
+	return unpackEnvelope(env)
+}
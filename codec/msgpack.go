@@ -0,0 +1,26 @@
+package codec
+
+import (
+	"github.com/vmihailenco/msgpack/v4"
+)
+
+// msgpackCodec encodes errors with the MessagePack format.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string { return "msgpack" }
+
+func (msgpackCodec) Marshal(err error) ([]byte, error) {
+	env, packErr := packEnvelope(err)
+	if packErr != nil {
+		return nil, packErr
+	}
+	return msgpack.Marshal(env)
+}
+
+func (msgpackCodec) Unmarshal(data []byte) (error, error) {
+	env := &envelope{}
+	if err := msgpack.Unmarshal(data, env); err != nil {
+		return nil, err
+	}
+	return unpackEnvelope(env)
+}
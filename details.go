@@ -1,7 +1,9 @@
 package errors
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"strings"
 	"time"
 
@@ -24,54 +26,225 @@ var (
 	// NotFound means some requested entity (e.g., file or directory) was
 	// not found.
 	NotFound error = &MissingFailure{}
+
+	// DataLoss indicates unrecoverable data loss or corruption. It must not
+	// be used for transient failures (use Unavailable instead for those
+	// errors).
+	DataLoss error = &DataLossFailure{}
 )
 
+// Now returns the current time
+var Now = time.Now
+
+// Time returns the time at which the failure was created. It returns the
+// zero time when no error in the chain carries a timestamp, e.g. for the
+// sentinel errors.
+func Time(err error) time.Time {
+	for e := err; e != nil; e = Unwrap(e) {
+		switch e := e.(type) {
+		case *AvailabilityFailure:
+			return e.OccurredAt
+		case *QuotaFailure:
+			return e.OccurredAt
+		case *PreconditionFailure:
+			return e.OccurredAt
+		case *BadRequest:
+			return e.OccurredAt
+		case *ConflictFailure:
+			return e.OccurredAt
+		case *MissingFailure:
+			return e.OccurredAt
+		case *PermissionFailure:
+			return e.OccurredAt
+		case *AuthenticationFailure:
+			return e.OccurredAt
+		case *DataLossFailure:
+			return e.OccurredAt
+		case *InternalFailure:
+			return e.OccurredAt
+		case *ExistsFailure:
+			return e.OccurredAt
+		case *NotImplementedFailure:
+			return e.OccurredAt
+		case *OutOfRangeFailure:
+			return e.OccurredAt
+		case *TimeoutFailure:
+			return e.OccurredAt
+		case *CancelledFailure:
+			return e.OccurredAt
+		}
+	}
+	return time.Time{}
+}
+
+// WithTime records `t` as the creation time of the first failure in the
+// chain of `err`. It is used by transports to restore the original creation
+// time when unpacking an error received from a remote service.
+func WithTime(err error, t time.Time) error {
+	for e := err; e != nil; e = Unwrap(e) {
+		switch e := e.(type) {
+		case *AvailabilityFailure:
+			e.OccurredAt = t
+			return err
+		case *QuotaFailure:
+			e.OccurredAt = t
+			return err
+		case *PreconditionFailure:
+			e.OccurredAt = t
+			return err
+		case *BadRequest:
+			e.OccurredAt = t
+			return err
+		case *ConflictFailure:
+			e.OccurredAt = t
+			return err
+		case *MissingFailure:
+			e.OccurredAt = t
+			return err
+		case *PermissionFailure:
+			e.OccurredAt = t
+			return err
+		case *AuthenticationFailure:
+			e.OccurredAt = t
+			return err
+		case *DataLossFailure:
+			e.OccurredAt = t
+			return err
+		case *InternalFailure:
+			e.OccurredAt = t
+			return err
+		case *ExistsFailure:
+			e.OccurredAt = t
+			return err
+		case *NotImplementedFailure:
+			e.OccurredAt = t
+			return err
+		case *OutOfRangeFailure:
+			e.OccurredAt = t
+			return err
+		case *TimeoutFailure:
+			e.OccurredAt = t
+			return err
+		case *CancelledFailure:
+			e.OccurredAt = t
+			return err
+		}
+	}
+	return err
+}
+
+// WithDetails annotates `err` with the given details. Details are arbitrary
+// values (e.g. RetryInfo, LocalisedMessage, or custom structs) that transports
+// can serialise alongside the error.
+// If err is nil, WithDetails returns nil.
+func WithDetails(err error, details ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	if len(details) == 0 {
+		return err
+	}
+	return &withDetails{err, details}
+}
+
+// Details returns all details attached to `err` and the errors it wraps.
+// It returns nil when no detail is attached.
+func Details(err error) []interface{} {
+	var details []interface{}
+	for err != nil {
+		if e, ok := err.(*withDetails); ok {
+			details = append(details, e.details...)
+		}
+		err = Unwrap(err)
+	}
+	return details
+}
+
+type withDetails struct {
+	error
+
+	details []interface{}
+}
+
+// Cause returns the wrapped error
+func (e *withDetails) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *withDetails) Unwrap() error { return e.error }
+
 // WithPermissionDenied wraps `parent` with a `PermissionFailure`
 func WithPermissionDenied(parent error) error {
-	return &PermissionFailure{parent}
+	return notify(&PermissionFailure{error: parent, OccurredAt: Now()})
 }
 
 // WithUnauthenticated wraps `parent` with an `AuthenticationFailure`
 func WithUnauthenticated(parent error) error {
-	return &AuthenticationFailure{parent}
+	return notify(&AuthenticationFailure{error: parent, OccurredAt: Now()})
 }
 
 // WithNotFound wraps `parent` with a `MissingFailure`
 func WithNotFound(parent error) error {
-	return &MissingFailure{parent}
+	return notify(&MissingFailure{error: parent, OccurredAt: Now()})
+}
+
+// WithDeadlineExceeded wraps `parent` with a `TimeoutFailure`, so the
+// underlying cause is preserved instead of being flattened into the bare
+// `context.DeadlineExceeded` sentinel.
+func WithDeadlineExceeded(parent error) error {
+	return notify(&TimeoutFailure{error: parent, OccurredAt: Now()})
+}
+
+// WithCanceled wraps `parent` with a `CancelledFailure`
+func WithCanceled(parent error) error {
+	return notify(&CancelledFailure{error: parent, OccurredAt: Now()})
+}
+
+// WithDataLoss wraps `parent` with a `DataLossFailure`
+func WithDataLoss(parent error) error {
+	return notify(&DataLossFailure{error: parent, OccurredAt: Now()})
+}
+
+// WithNotImplemented wraps `parent` with a `NotImplementedFailure`
+func WithNotImplemented(parent error) error {
+	return notify(&NotImplementedFailure{error: parent, OccurredAt: Now()})
+}
+
+// WithInternal wraps `parent` with an `InternalFailure`
+func WithInternal(parent error) error {
+	return notify(&InternalFailure{error: parent, OccurredAt: Now()})
 }
 
 // WithBad wraps `parent` with a `BadRequest`
 func WithBad(parent error, violations ...*FieldViolation) error {
-	return &BadRequest{parent, violations}
+	return notify(&BadRequest{error: parent, OccurredAt: Now(), Violations: violations})
 }
 
 // WithFailedPrecondition wraps `parent` with a `PreconditionFailure`
 func WithFailedPrecondition(parent error, violations ...*PreconditionViolation) error {
-	return &PreconditionFailure{parent, violations}
+	return notify(&PreconditionFailure{error: parent, OccurredAt: Now(), Violations: violations})
 }
 
 // WithAborted wraps `parent` with a `ConflictFailure`
 func WithAborted(parent error, violations ...*ConflictViolation) error {
-	return &ConflictFailure{parent, violations}
+	return notify(&ConflictFailure{error: parent, OccurredAt: Now(), Violations: violations})
 }
 
 // WithUnavailable wraps `parent` with an `AvailabilityFailure`
 func WithUnavailable(parent error, retryDelay time.Duration) error {
-	return &AvailabilityFailure{parent, RetryInfo{RetryDelay: retryDelay}}
+	return notify(&AvailabilityFailure{error: parent, OccurredAt: Now(), RetryInfo: RetryInfo{RetryDelay: retryDelay}})
 }
 
 // WithResourceExhausted wraps `parent` with a `QuotaFailure`
 func WithResourceExhausted(parent error, violations ...*QuotaViolation) error {
-	return &QuotaFailure{parent, violations}
+	return notify(&QuotaFailure{error: parent, OccurredAt: Now(), Violations: violations})
 }
 
 // Bad indicates client specified an invalid argument.
 // Note that this differs from FailedPrecondition. It indicates arguments
 // that are problematic regardless of the state of the system
 // (e.g., a malformed file name).
-func Bad(violations ...*FieldViolation) error {
-	return &BadRequest{Violations: violations}
+func Bad(opts ...Option) error {
+	return notify(applyOptions(&BadRequest{OccurredAt: Now()}, opts...))
 }
 
 // FailedPrecondition indicates operation was rejected because the
@@ -93,8 +266,8 @@ func Bad(violations ...*FieldViolation) error {
 //      REST Get/Update/Delete on a resource and the resource on the
 //      server does not match the condition. E.g., conflicting
 //      read-modify-write on the same resource.
-func FailedPrecondition(violations ...*PreconditionViolation) error {
-	return &PreconditionFailure{Violations: violations}
+func FailedPrecondition(opts ...Option) error {
+	return notify(applyOptions(&PreconditionFailure{OccurredAt: Now()}, opts...))
 }
 
 // Aborted indicates the operation was aborted, typically due to a
@@ -103,8 +276,19 @@ func FailedPrecondition(violations ...*PreconditionViolation) error {
 //
 // See litmus test above for deciding between FailedPrecondition,
 // Aborted, and Unavailable.
-func Aborted(violations ...*ConflictViolation) error {
-	return &ConflictFailure{Violations: violations}
+func Aborted(opts ...Option) error {
+	return notify(applyOptions(&ConflictFailure{OccurredAt: Now()}, opts...))
+}
+
+// AlreadyExists indicates an attempt to create an entity that already
+// exists. Unlike Aborted, retrying is pointless: the resource is there.
+func AlreadyExists(resource string, opts ...Option) error {
+	return notify(applyOptions(&ExistsFailure{OccurredAt: Now(), Resource: resource}, opts...))
+}
+
+// WithAlreadyExists wraps `parent` with an `ExistsFailure`
+func WithAlreadyExists(parent error, resource string) error {
+	return notify(&ExistsFailure{error: parent, OccurredAt: Now(), Resource: resource})
 }
 
 // Unavailable indicates the service is currently unavailable.
@@ -113,84 +297,172 @@ func Aborted(violations ...*ConflictViolation) error {
 //
 // See litmus test above for deciding between FailedPrecondition,
 // Aborted, and Unavailable.
-func Unavailable(retryDelay time.Duration) error {
-	return &AvailabilityFailure{RetryInfo: RetryInfo{RetryDelay: retryDelay}}
+func Unavailable(retryDelay time.Duration, opts ...Option) error {
+	return notify(applyOptions(
+		&AvailabilityFailure{OccurredAt: Now(), RetryInfo: RetryInfo{RetryDelay: retryDelay}},
+		opts...,
+	))
+}
+
+// UnavailableBecause indicates the service is currently unavailable for a
+// known reason, so operations can distinguish planned maintenance from
+// overload when alerting.
+//
+// See litmus test above for deciding between FailedPrecondition,
+// Aborted, and Unavailable.
+func UnavailableBecause(reason AvailabilityReason, retryDelay time.Duration, opts ...Option) error {
+	return notify(applyOptions(
+		&AvailabilityFailure{
+			OccurredAt: Now(),
+			Reason:     reason,
+			RetryInfo:  RetryInfo{RetryDelay: retryDelay},
+		},
+		opts...,
+	))
 }
 
 // ResourceExhausted indicates some resource has been exhausted, perhaps
 // a per-user quota, or perhaps the entire file system is out of space.
-func ResourceExhausted(violations ...*QuotaViolation) error {
-	return &QuotaFailure{Violations: violations}
+func ResourceExhausted(opts ...Option) error {
+	return notify(applyOptions(&QuotaFailure{OccurredAt: Now()}, opts...))
+}
+
+// OutOfRange means operation was attempted past the valid range.
+// E.g., seeking or reading past end of file.
+//
+// Unlike InvalidArgument, this error indicates a problem that may
+// be fixed if the system state changes. For example, a 32-bit file
+// system will generate InvalidArgument if asked to read at an
+// offset that is not in the range [0,2^32-1], but it will generate
+// OutOfRange if asked to read from an offset past the current
+// file size.
+func OutOfRange(opts ...Option) error {
+	return notify(applyOptions(&OutOfRangeFailure{OccurredAt: Now()}, opts...))
 }
 
+// WithOutOfRange wraps `parent` with an `OutOfRangeFailure`
+func WithOutOfRange(parent error, violations ...*RangeViolation) error {
+	return notify(&OutOfRangeFailure{error: parent, OccurredAt: Now(), Violations: violations})
+}
+
+// The Is* predicates traverse the wrap chain with `As` semantics, so they
+// keep working when a failure is wrapped with fmt.Errorf("%w", err),
+// pkg/errors, or WithDetails.
+
 func IsPermissionDenied(err error) bool {
-	if err == nil {
-		return false
-	}
-	_, ok := err.(*PermissionFailure)
-	return ok
+	var f *PermissionFailure
+	return As(err, &f)
 }
 
 func IsUnauthenticated(err error) bool {
-	if err == nil {
-		return false
-	}
-	_, ok := err.(*AuthenticationFailure)
-	return ok
+	var f *AuthenticationFailure
+	return As(err, &f)
 }
 
 func IsNotFound(err error) bool {
-	if err == nil {
-		return false
-	}
-	_, ok := err.(*MissingFailure)
-	return ok
+	var f *MissingFailure
+	return As(err, &f)
 }
 
 func IsBad(err error) bool {
-	if err == nil {
-		return false
-	}
-	_, ok := err.(*BadRequest)
-	return ok
+	var f *BadRequest
+	return As(err, &f)
 }
 
 func IsFailedPrecondition(err error) bool {
-	if err == nil {
-		return false
-	}
-	_, ok := err.(*PreconditionFailure)
-	return ok
+	var f *PreconditionFailure
+	return As(err, &f)
+}
+
+func IsAlreadyExists(err error) bool {
+	var f *ExistsFailure
+	return As(err, &f)
 }
 
 func IsAborted(err error) bool {
-	if err == nil {
-		return false
-	}
-	_, ok := err.(*ConflictFailure)
-	return ok
+	var f *ConflictFailure
+	return As(err, &f)
 }
 
 func IsUnavailable(err error) bool {
-	if err == nil {
-		return false
-	}
-	_, ok := err.(*AvailabilityFailure)
-	return ok
+	var f *AvailabilityFailure
+	return As(err, &f)
+}
+
+func IsDeadlineExceeded(err error) bool {
+	return Is(err, context.DeadlineExceeded)
+}
+
+func IsCanceled(err error) bool {
+	return Is(err, context.Canceled)
+}
+
+func IsOutOfRange(err error) bool {
+	var f *OutOfRangeFailure
+	return As(err, &f)
+}
+
+func IsDataLoss(err error) bool {
+	var f *DataLossFailure
+	return As(err, &f)
 }
 
 func IsResourceExhausted(err error) bool {
-	if err == nil {
-		return false
-	}
-	_, ok := err.(*QuotaFailure)
-	return ok
+	var f *QuotaFailure
+	return As(err, &f)
 }
 
 type AvailabilityFailure struct {
 	error
 
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
 	RetryInfo RetryInfo
+	// Reason optionally distinguishes why the service is unavailable.
+	Reason AvailabilityReason
+}
+
+// AvailabilityReason distinguishes why a service is unavailable.
+type AvailabilityReason int
+
+const (
+	// ReasonUnspecified means the cause of the unavailability is unknown.
+	ReasonUnspecified AvailabilityReason = iota
+	// ReasonMaintenance means the service is down for planned maintenance.
+	ReasonMaintenance
+	// ReasonOverload means the service is shedding load.
+	ReasonOverload
+	// ReasonDependency means a dependency of the service is unavailable.
+	ReasonDependency
+)
+
+func (r AvailabilityReason) String() string {
+	switch r {
+	case ReasonMaintenance:
+		return "MAINTENANCE"
+	case ReasonOverload:
+		return "OVERLOAD"
+	case ReasonDependency:
+		return "DEPENDENCY"
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// ParseAvailabilityReason returns the reason matching the given string
+// representation, or ReasonUnspecified when it is unknown.
+func ParseAvailabilityReason(s string) AvailabilityReason {
+	switch s {
+	case "MAINTENANCE":
+		return ReasonMaintenance
+	case "OVERLOAD":
+		return ReasonOverload
+	case "DEPENDENCY":
+		return ReasonDependency
+	default:
+		return ReasonUnspecified
+	}
 }
 
 func (e *AvailabilityFailure) Error() string {
@@ -200,6 +472,12 @@ func (e *AvailabilityFailure) Error() string {
 	return "service temporarily unavailable"
 }
 
+// Cause returns the wrapped error
+func (e *AvailabilityFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *AvailabilityFailure) Unwrap() error { return e.error }
+
 // Describes how a quota check failed.
 //
 // For example if a daily limit was exceeded for the calling project,
@@ -213,6 +491,9 @@ func (e *AvailabilityFailure) Error() string {
 type QuotaFailure struct {
 	error
 
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
 	// Describes all quota violations.
 	Violations []*QuotaViolation
 }
@@ -229,6 +510,12 @@ func (e *QuotaFailure) Error() string {
 	return maybeWrap(e.error, strings.Join(s, ". ")).Error()
 }
 
+// Cause returns the wrapped error
+func (e *QuotaFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *QuotaFailure) Unwrap() error { return e.error }
+
 // A message type used to describe a single quota violation. For example, a
 // daily quota or a custom quota that was exceeded.
 type QuotaViolation struct {
@@ -244,6 +531,15 @@ type QuotaViolation struct {
 	// For example: "Service disabled" or "Daily Limit for read operations
 	// exceeded".
 	Description string
+	// The quota limit, and the current usage that exceeded it. Optional.
+	Limit   int64
+	Current int64
+	// The time at which the quota block resets. Clients can derive how long
+	// the block lasts from it. Optional.
+	ResetTime time.Time
+	// Internal marks the violation as internal-only. Redaction strips it
+	// before the error crosses a trust boundary (see Redact).
+	Internal bool
 }
 
 func (v *QuotaViolation) String() string {
@@ -258,6 +554,9 @@ func (v *QuotaViolation) String() string {
 type PreconditionFailure struct {
 	error
 
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
 	// Describes all precondition violations.
 	Violations []*PreconditionViolation
 }
@@ -274,6 +573,12 @@ func (e *PreconditionFailure) Error() string {
 	return maybeWrap(e.error, strings.Join(s, ". ")).Error()
 }
 
+// Cause returns the wrapped error
+func (e *PreconditionFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *PreconditionFailure) Unwrap() error { return e.error }
+
 // A message type used to describe a single precondition failure.
 type PreconditionViolation struct {
 	// The type of PreconditionFailure. We recommend using a service-specific
@@ -289,17 +594,40 @@ type PreconditionViolation struct {
 	//
 	// For example: "Terms of service not accepted".
 	Description string
+	// Locales carries per-locale variants of Description, keyed by BCP 47
+	// language tag. Optional.
+	Locales LocalisedString
+	// Internal marks the violation as internal-only. Redaction strips it
+	// before the error crosses a trust boundary (see Redact).
+	Internal bool
 }
 
 func (v *PreconditionViolation) String() string {
 	return strings.Join([]string{v.Type, v.Subject, v.Description}, " - ")
 }
 
+// LocalizedDescription returns the description variant matching the
+// preferred locales, falling back to Description when the violation
+// carries no matching translation
+func (v *PreconditionViolation) LocalizedDescription(preferred ...language.Tag) string {
+	if len(v.Locales) == 0 {
+		return v.Description
+	}
+	msg, err := v.Locales.MatchTag(preferred...)
+	if err != nil || msg == "" {
+		return v.Description
+	}
+	return msg
+}
+
 // Describes violations in a client request. This error type focuses on the
 // syntactic aspects of the request.
 type BadRequest struct {
 	error
 
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
 	// Describes all violations in a client request.
 	Violations []*FieldViolation
 }
@@ -316,6 +644,12 @@ func (e *BadRequest) Error() string {
 	return maybeWrap(e.error, strings.Join(s, ". ")).Error()
 }
 
+// Cause returns the wrapped error
+func (e *BadRequest) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *BadRequest) Unwrap() error { return e.error }
+
 // A message type used to describe a single bad request field.
 type FieldViolation struct {
 	// A path leading to a field in the request body. The value will be a
@@ -324,10 +658,42 @@ type FieldViolation struct {
 	Field string
 	// A description of why the request element is bad.
 	Description string
+	// A machine-readable code for why the request element is bad, e.g.
+	// "REQUIRED" or "TOO_LONG", which UIs can use to render precise
+	// validation feedback without parsing the description. Optional.
+	Reason string
+	// The value that was expected for the field. Optional.
+	Expected string
+	// The value that was received for the field. Optional.
+	Actual string
+	// Locales carries per-locale variants of Description, keyed by BCP 47
+	// language tag. Optional.
+	Locales LocalisedString
+	// Internal marks the violation as internal-only. Redaction strips it
+	// before the error crosses a trust boundary (see Redact).
+	Internal bool
 }
 
 func (v *FieldViolation) String() string {
-	return strings.Join([]string{v.Field, v.Description}, " - ")
+	s := []string{v.Field, v.Description}
+	if v.Reason != "" {
+		s = append(s, v.Reason)
+	}
+	return strings.Join(s, " - ")
+}
+
+// LocalizedDescription returns the description variant matching the
+// preferred locales, falling back to Description when the violation
+// carries no matching translation
+func (v *FieldViolation) LocalizedDescription(preferred ...language.Tag) string {
+	if len(v.Locales) == 0 {
+		return v.Description
+	}
+	msg, err := v.Locales.MatchTag(preferred...)
+	if err != nil || msg == "" {
+		return v.Description
+	}
+	return msg
 }
 
 // Describes violations in a client request. This error type focuses on the
@@ -335,6 +701,9 @@ func (v *FieldViolation) String() string {
 type ConflictFailure struct {
 	error
 
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
 	// Describes all violations in a client request.
 	Violations []*ConflictViolation
 }
@@ -351,42 +720,203 @@ func (e *ConflictFailure) Error() string {
 	return maybeWrap(e.error, strings.Join(s, ". ")).Error()
 }
 
+// Cause returns the wrapped error
+func (e *ConflictFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *ConflictFailure) Unwrap() error { return e.error }
+
 type ConflictViolation struct {
 	// resource on which the conflict occurred.
 	// For example, "user:<uuid>" or "billing/invoice:<uuid>".
 	Resource string
 	// A description of why the request element is bad.
 	Description string
+	// Internal marks the violation as internal-only. Redaction strips it
+	// before the error crosses a trust boundary (see Redact).
+	Internal bool
 }
 
 func (v *ConflictViolation) String() string {
 	return strings.Join([]string{v.Resource, v.Description}, " - ")
 }
 
+// ExistsFailure indicates an attempt to create an entity that already
+// exists, e.g. a duplicate account registration. It is distinct from
+// ConflictFailure: retrying the request cannot succeed.
+type ExistsFailure struct {
+	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
+	// Resource names the entity that already exists.
+	// For example, "user:<uuid>" or "billing/invoice:<uuid>".
+	Resource string
+}
+
+func (e *ExistsFailure) Error() string {
+	if e.Resource == "" {
+		return maybeWrap(e.error, "already exists").Error()
+	}
+	return maybeWrap(e.error, fmt.Sprintf("%s already exists", e.Resource)).Error()
+}
+
+// Cause returns the wrapped error
+func (e *ExistsFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *ExistsFailure) Unwrap() error { return e.error }
+
+// OutOfRangeFailure describes a request that went past the valid range,
+// e.g. a pagination cursor that iterated past the last page.
+type OutOfRangeFailure struct {
+	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
+	// Describes all range violations.
+	Violations []*RangeViolation
+}
+
+func (e *OutOfRangeFailure) Error() string {
+	if len(e.Violations) == 0 {
+		return maybeWrap(e.error, "out of range").Error()
+	}
+
+	s := make([]string, len(e.Violations))
+	for i := range e.Violations {
+		s[i] = e.Violations[i].Description()
+	}
+	return maybeWrap(e.error, strings.Join(s, ". ")).Error()
+}
+
+// Cause returns the wrapped error
+func (e *OutOfRangeFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *OutOfRangeFailure) Unwrap() error { return e.error }
+
+// A message type used to describe a single out of range field.
+type RangeViolation struct {
+	// A path leading to the field which is out of range.
+	Field string
+	// The minimum and maximum values allowed (inclusive).
+	Min, Max int64
+	// The actual value received.
+	Actual int64
+	// Internal marks the violation as internal-only. Redaction strips it
+	// before the error crosses a trust boundary (see Redact).
+	Internal bool
+}
+
+// Description returns a human readable description of the violation
+func (v *RangeViolation) Description() string {
+	return fmt.Sprintf("value %d out of range [%d, %d]", v.Actual, v.Min, v.Max)
+}
+
+func (v *RangeViolation) String() string {
+	return strings.Join([]string{v.Field, v.Description()}, " - ")
+}
+
+// DataLossFailure indicates unrecoverable data loss or corruption, which
+// differs from a generic internal error in that retrying cannot succeed.
+type DataLossFailure struct {
+	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
+	// DebugInfo optionally describes the corruption for operators. It should
+	// be stripped before crossing a trust boundary.
+	DebugInfo *DebugInfo
+}
+
+func (e *DataLossFailure) Error() string {
+	return maybeWrap(e.error, "unrecoverable data loss or corruption").Error()
+}
+
+// Cause returns the wrapped error
+func (e *DataLossFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *DataLossFailure) Unwrap() error { return e.error }
+
+// InternalFailure indicates an invariant expected by the underlying system
+// has been broken, e.g. a recovered panic.
+type InternalFailure struct {
+	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+
+	// DebugInfo optionally describes the failure for operators. It should
+	// be stripped before crossing a trust boundary.
+	DebugInfo *DebugInfo
+}
+
+func (e *InternalFailure) Error() string {
+	return maybeWrap(e.error, "internal failure").Error()
+}
+
+// Cause returns the wrapped error
+func (e *InternalFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *InternalFailure) Unwrap() error { return e.error }
+
 type MissingFailure struct {
 	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
 }
 
 func (e *MissingFailure) Error() string {
 	return "resource not found"
 }
 
+// Cause returns the wrapped error
+func (e *MissingFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *MissingFailure) Unwrap() error { return e.error }
+
 type PermissionFailure struct {
 	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
 }
 
 func (e *PermissionFailure) Error() string {
 	return "permission denied"
 }
 
+// Cause returns the wrapped error
+func (e *PermissionFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *PermissionFailure) Unwrap() error { return e.error }
+
 type AuthenticationFailure struct {
 	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
 }
 
 func (e *AuthenticationFailure) Error() string {
 	return "failed to authenticate request"
 }
 
+// Cause returns the wrapped error
+func (e *AuthenticationFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *AuthenticationFailure) Unwrap() error { return e.error }
+
 // RetryInfo describes when the clients can retry a failed request.
 // Clients could ignore the recommendation here or retry when this information
 // is missing from error responses.
@@ -403,6 +933,169 @@ func (e *AuthenticationFailure) Error() string {
 type RetryInfo struct {
 	// Clients should wait at least this long between retrying the same request.
 	RetryDelay time.Duration
+	// RetryAt optionally sets the absolute time before which clients should
+	// not retry. When set, it takes precedence over RetryDelay, which avoids
+	// clock skew issues when the delay was computed on another node.
+	RetryAt time.Time
+	// Jitter is the fraction (0 to 1] of the delay that is randomised, so
+	// that many clients receiving the same recommendation do not retry in
+	// lockstep and stampede the service.
+	Jitter float64
+}
+
+// Delay computes the effective wait before retrying at `now`. The result is
+// the remaining time until RetryAt when set, RetryDelay otherwise, spread
+// uniformly within [(1-Jitter)*delay, delay]. It is never negative.
+func (i RetryInfo) Delay(now time.Time) time.Duration {
+	d := i.RetryDelay
+	if !i.RetryAt.IsZero() {
+		d = i.RetryAt.Sub(now)
+	}
+	if d <= 0 {
+		return 0
+	}
+	if i.Jitter > 0 {
+		jitter := i.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+	return d
+}
+
+// TimeoutFailure indicates the deadline expired before the operation could
+// complete. It matches `context.DeadlineExceeded` with `errors.Is`, but keeps
+// the underlying cause reachable through `Unwrap`.
+type TimeoutFailure struct {
+	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+}
+
+func (e *TimeoutFailure) Error() string {
+	return maybeWrap(e.error, "deadline exceeded").Error()
+}
+
+// Is reports whether `target` is `context.DeadlineExceeded`
+func (e *TimeoutFailure) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// Cause returns the wrapped error
+func (e *TimeoutFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *TimeoutFailure) Unwrap() error { return e.error }
+
+// CancelledFailure indicates the operation was cancelled, typically by the
+// caller. It matches `context.Canceled` with `errors.Is`, but keeps the
+// underlying cause reachable through `Unwrap`.
+type CancelledFailure struct {
+	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+}
+
+func (e *CancelledFailure) Error() string {
+	return maybeWrap(e.error, "operation cancelled").Error()
+}
+
+// Is reports whether `target` is `context.Canceled`
+func (e *CancelledFailure) Is(target error) bool {
+	return target == context.Canceled
+}
+
+// Cause returns the wrapped error
+func (e *CancelledFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *CancelledFailure) Unwrap() error { return e.error }
+
+// NotImplementedFailure indicates the operation is not implemented or not
+// supported by the service.
+type NotImplementedFailure struct {
+	error
+
+	// OccurredAt records when the failure was created.
+	OccurredAt time.Time
+}
+
+func (e *NotImplementedFailure) Error() string {
+	return maybeWrap(e.error, "not implemented").Error()
+}
+
+// Cause returns the wrapped error
+func (e *NotImplementedFailure) Cause() error { return e.error }
+
+// Unwrap returns the wrapped error
+func (e *NotImplementedFailure) Unwrap() error { return e.error }
+
+// DebugInfo describes additional debugging info.
+//
+// It is only intended for operators and must not be returned to external
+// clients.
+type DebugInfo struct {
+	// The stack trace entries indicating where the error occurred.
+	StackEntries []string
+	// Additional debugging information provided by the server.
+	Detail string
+}
+
+// Help provides links to documentation or for performing an out-of-band
+// action which can be attached to an error.
+type Help struct {
+	// URL(s) pointing to additional information on handling the current error.
+	Links []*HelpLink
+}
+
+// HelpLink describes a URL link.
+type HelpLink struct {
+	// Describes what the link offers.
+	Description string
+	// The URL of the link.
+	URL string
+}
+
+// Metadata carries structured key/value facts (e.g. tenant, shard, region)
+// attached to an error.
+type Metadata map[string]string
+
+// WithMeta annotates `err` with structured key/value facts, which both
+// transports serialise alongside the error.
+// If err is nil, WithMeta returns nil.
+func WithMeta(err error, meta map[string]string) error {
+	if err == nil {
+		return nil
+	}
+	if len(meta) == 0 {
+		return err
+	}
+	m := make(Metadata, len(meta))
+	for k, v := range meta {
+		m[k] = v
+	}
+	return WithDetails(err, m)
+}
+
+// Meta returns the metadata attached to `err` and the errors it wraps.
+// When the same key is attached multiple times, the outermost value wins.
+func Meta(err error) map[string]string {
+	meta := map[string]string{}
+	for _, d := range Details(err) {
+		m, ok := d.(Metadata)
+		if !ok {
+			continue
+		}
+		for k, v := range m {
+			if _, ok := meta[k]; !ok {
+				meta[k] = v
+			}
+		}
+	}
+	return meta
 }
 
 // Provides a localized error message that is safe to return to the user
@@ -416,40 +1109,59 @@ type LocalisedMessage struct {
 	Message string
 }
 
-// LocalisedString is a string that can contain multiple translations
-//
-// The locale used following the specification defined at
-// http://www.rfc-editor.org/rfc/bcp/bcp47.txt.
-// Examples are: "en-US", "fr-CH", "es-MX"
-type LocalisedString map[string]string
+// apply implements Option, so a localised message can be attached at
+// construction time
+func (m *LocalisedMessage) apply(err error) error {
+	return WithDetails(err, m)
+}
+
+// WithLocalizedMessage annotates `err` with a localised error message that is
+// safe to return to the user. It can be called multiple times to attach one
+// message per locale.
+// If err is nil, WithLocalizedMessage returns nil.
+func WithLocalizedMessage(err error, locale language.Tag, message string) error {
+	return WithDetails(err, &LocalisedMessage{
+		Locale:  locale,
+		Message: message,
+	})
+}
 
-// Match finds the best supported language based on the preferred list and
-// the languages for which there exists translations
-func (s LocalisedString) Match(locales ...string) string {
-	if len(s) == 0 {
+// LocalizedMessage returns the localised message attached to `err` that best
+// matches the preferred locales. When no preference is given, or none of the
+// attached locales matches, the first attached message is returned. It
+// returns an empty string when `err` carries no localised message.
+func LocalizedMessage(err error, preferred ...language.Tag) string {
+	var messages []*LocalisedMessage
+	for _, d := range Details(err) {
+		if m, ok := d.(*LocalisedMessage); ok {
+			messages = append(messages, m)
+		}
+	}
+	if len(messages) == 0 {
 		return ""
 	}
-
-	available := make([]language.Tag, 0, len(s))
-	for t := range s {
-		available = append(available, language.MustParse(t))
+	if len(preferred) == 0 {
+		return messages[0].Message
 	}
 
-	preferred := make([]language.Tag, 0, len(locales))
-	for t := range s {
-		tag, err := language.Parse(t)
-		if err != nil {
-			panic(err)
-		}
-		preferred = append(preferred, tag)
+	available := make([]language.Tag, len(messages))
+	for i, m := range messages {
+		available[i] = m.Locale
 	}
-
-	t, _, _ := language.NewMatcher(available).Match(preferred...)
-	return s[t.String()]
+	_, i, _ := language.NewMatcher(available).Match(preferred...)
+	return messages[i].Message
 }
 
+// LocalisedString is a string that can contain multiple translations
+//
+// The locale used following the specification defined at
+// http://www.rfc-editor.org/rfc/bcp/bcp47.txt.
+// Examples are: "en-US", "fr-CH", "es-MX"
+type LocalisedString map[string]string
+
 func (s LocalisedString) String() string {
-	return s.Match(language.English.String())
+	msg, _ := s.Match(language.English.String())
+	return msg
 }
 
 func maybeWrap(err error, message string) error {
@@ -0,0 +1,73 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+// TestPredicates_Wrapped ensures the Is* predicates keep matching failures
+// wrapped with the common wrapping patterns.
+func TestPredicates_Wrapped(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		pred func(error) bool
+	}{
+		{"not found direct", errors.NotFound, errors.IsNotFound},
+		{"not found std wrap", fmt.Errorf("load user: %w", errors.NotFound), errors.IsNotFound},
+		{"not found pkg wrap", errors.Wrap(errors.NotFound, "load user"), errors.IsNotFound},
+		{"not found details", errors.WithDetails(errors.NotFound, "x"), errors.IsNotFound},
+		{"bad direct", errors.Bad(), errors.IsBad},
+		{"bad std wrap", fmt.Errorf("save: %w", errors.Bad()), errors.IsBad},
+		{"bad message wrap", errors.WithMessage(errors.Bad(), "save"), errors.IsBad},
+		{"permission denied wrap", errors.Wrap(errors.PermissionDenied, "op"), errors.IsPermissionDenied},
+		{"unauthenticated wrap", fmt.Errorf("%w", errors.Unauthenticated), errors.IsUnauthenticated},
+		{"precondition wrap", errors.Wrap(errors.FailedPrecondition(), "op"), errors.IsFailedPrecondition},
+		{"aborted wrap", fmt.Errorf("%w", errors.Aborted()), errors.IsAborted},
+		{"already exists wrap", fmt.Errorf("%w", errors.AlreadyExists("user:42")), errors.IsAlreadyExists},
+		{"unavailable wrap", errors.Wrap(errors.Unavailable(0), "op"), errors.IsUnavailable},
+		{"resource exhausted wrap", fmt.Errorf("%w", errors.ResourceExhausted()), errors.IsResourceExhausted},
+		{"out of range wrap", fmt.Errorf("%w", errors.OutOfRange()), errors.IsOutOfRange},
+		{"data loss wrap", errors.Wrap(errors.DataLoss, "op"), errors.IsDataLoss},
+		{"deadline sentinel", context.DeadlineExceeded, errors.IsDeadlineExceeded},
+		{"deadline typed wrap", fmt.Errorf("%w", errors.WithDeadlineExceeded(nil)), errors.IsDeadlineExceeded},
+		{"canceled sentinel", context.Canceled, errors.IsCanceled},
+		{"canceled typed wrap", errors.WithCanceled(context.Canceled), errors.IsCanceled},
+	}
+
+	for _, test := range tests {
+		if !test.pred(test.err) {
+			t.Errorf("%s: expect predicate to match %v", test.name, test.err)
+		}
+	}
+}
+
+func TestPredicates_NoMatch(t *testing.T) {
+	err := errors.New("boom")
+	preds := map[string]func(error) bool{
+		"IsNotFound":           errors.IsNotFound,
+		"IsBad":                errors.IsBad,
+		"IsPermissionDenied":   errors.IsPermissionDenied,
+		"IsUnauthenticated":    errors.IsUnauthenticated,
+		"IsFailedPrecondition": errors.IsFailedPrecondition,
+		"IsAborted":            errors.IsAborted,
+		"IsAlreadyExists":      errors.IsAlreadyExists,
+		"IsUnavailable":        errors.IsUnavailable,
+		"IsResourceExhausted":  errors.IsResourceExhausted,
+		"IsOutOfRange":         errors.IsOutOfRange,
+		"IsDataLoss":           errors.IsDataLoss,
+		"IsDeadlineExceeded":   errors.IsDeadlineExceeded,
+		"IsCanceled":           errors.IsCanceled,
+	}
+	for name, pred := range preds {
+		if pred(err) {
+			t.Errorf("%s: expect predicate to not match %v", name, err)
+		}
+		if pred(nil) {
+			t.Errorf("%s: expect predicate to not match nil", name)
+		}
+	}
+}
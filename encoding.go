@@ -0,0 +1,245 @@
+package errors
+
+// The failure types implement encoding.TextMarshaler/TextUnmarshaler and
+// encoding.BinaryMarshaler/BinaryUnmarshaler, so they can be embedded in
+// payloads that already use generic encoders (e.g. gob shipments, cache
+// values, or schedule job payloads). Both encodings delegate to the JSON
+// envelope (see MarshalJSON), which preserves the full failure model.
+
+func (e *MissingFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *MissingFailure) UnmarshalText(data []byte) error {
+	f := &MissingFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *MissingFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *MissingFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *PermissionFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *PermissionFailure) UnmarshalText(data []byte) error {
+	f := &PermissionFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *PermissionFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *PermissionFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *AuthenticationFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *AuthenticationFailure) UnmarshalText(data []byte) error {
+	f := &AuthenticationFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *AuthenticationFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *AuthenticationFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *BadRequest) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *BadRequest) UnmarshalText(data []byte) error {
+	f := &BadRequest{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *BadRequest) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *BadRequest) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *PreconditionFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *PreconditionFailure) UnmarshalText(data []byte) error {
+	f := &PreconditionFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *PreconditionFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *PreconditionFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *ConflictFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *ConflictFailure) UnmarshalText(data []byte) error {
+	f := &ConflictFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *ConflictFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *ConflictFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *QuotaFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *QuotaFailure) UnmarshalText(data []byte) error {
+	f := &QuotaFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *QuotaFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *QuotaFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *AvailabilityFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *AvailabilityFailure) UnmarshalText(data []byte) error {
+	f := &AvailabilityFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *AvailabilityFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *AvailabilityFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *DataLossFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *DataLossFailure) UnmarshalText(data []byte) error {
+	f := &DataLossFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *DataLossFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *DataLossFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *InternalFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *InternalFailure) UnmarshalText(data []byte) error {
+	f := &InternalFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *InternalFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *InternalFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *OutOfRangeFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *OutOfRangeFailure) UnmarshalText(data []byte) error {
+	f := &OutOfRangeFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *OutOfRangeFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *OutOfRangeFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *TimeoutFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *TimeoutFailure) UnmarshalText(data []byte) error {
+	f := &TimeoutFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *TimeoutFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *TimeoutFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *CancelledFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *CancelledFailure) UnmarshalText(data []byte) error {
+	f := &CancelledFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *CancelledFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *CancelledFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *ExistsFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *ExistsFailure) UnmarshalText(data []byte) error {
+	f := &ExistsFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *ExistsFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *ExistsFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *NotImplementedFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *NotImplementedFailure) UnmarshalText(data []byte) error {
+	f := &NotImplementedFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *NotImplementedFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *NotImplementedFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+func (e *MultiFailure) MarshalText() ([]byte, error) { return MarshalJSON(e) }
+
+func (e *MultiFailure) UnmarshalText(data []byte) error {
+	f := &MultiFailure{}
+	if err := unmarshalFailure(data, &f); err != nil {
+		return err
+	}
+	*e = *f
+	return nil
+}
+
+func (e *MultiFailure) MarshalBinary() ([]byte, error)    { return e.MarshalText() }
+func (e *MultiFailure) UnmarshalBinary(data []byte) error { return e.UnmarshalText(data) }
+
+// unmarshalFailure decodes the JSON envelope into the failure pointed to by
+// target, which must be a pointer to a pointer of a failure type. It
+// returns an error when the envelope carries a different failure type.
+func unmarshalFailure(data []byte, target interface{}) error {
+	decoded, err := UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if !As(decoded, target) {
+		return Errorf("errors: cannot unmarshal %T into %T", decoded, target)
+	}
+	return nil
+}
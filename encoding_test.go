@@ -0,0 +1,60 @@
+package errors_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestFailure_TextRoundTrip(t *testing.T) {
+	want := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "invalid email address",
+	})
+
+	data, err := want.(*errors.BadRequest).MarshalText()
+	if err != nil {
+		t.Fatalf("expect MarshalText to succeed, but got error %v", err)
+	}
+
+	got := &errors.BadRequest{}
+	if err := got.UnmarshalText(data); err != nil {
+		t.Fatalf("expect UnmarshalText to succeed, but got error %v", err)
+	}
+	if !errors.Match(got, want) {
+		t.Errorf("expect errors to match after round trip, but got %v", got)
+	}
+}
+
+func TestFailure_GobRoundTrip(t *testing.T) {
+	want := errors.ResourceExhausted(&errors.QuotaViolation{
+		Subject:     "api-calls",
+		Description: "rate limit exceeded",
+		Limit:       100,
+	})
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatalf("expect gob encoding to succeed, but got error %v", err)
+	}
+	got := &errors.QuotaFailure{}
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Fatalf("expect gob decoding to succeed, but got error %v", err)
+	}
+	if !errors.Match(got, want) {
+		t.Errorf("expect errors to match after round trip, but got %v", got)
+	}
+}
+
+func TestFailure_UnmarshalText_Mismatch(t *testing.T) {
+	data, err := errors.MarshalJSON(errors.Aborted())
+	if err != nil {
+		t.Fatalf("expect MarshalJSON to succeed, but got error %v", err)
+	}
+	got := &errors.BadRequest{}
+	if err := got.UnmarshalText(data); err == nil {
+		t.Error("expect UnmarshalText to fail on a failure type mismatch")
+	}
+}
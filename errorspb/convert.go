@@ -0,0 +1,310 @@
+package errorspb
+
+import (
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/text/language"
+)
+
+// ToProto converts `err` into its canonical protobuf representation. It
+// returns nil when err is nil.
+func ToProto(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	if multi, ok := errors.AsMultiFailure(err); ok {
+		pb := &Error{Message: multi.Error()}
+		for _, member := range multi.Errors {
+			pb.Causes = append(pb.Causes, ToProto(member))
+		}
+		if len(pb.Causes) > 0 {
+			pb.Code = pb.Causes[0].Code
+		}
+		return pb
+	}
+
+	pb := &Error{
+		Code:          Code(errors.CodeOf(err)),
+		Message:       err.Error(),
+		CorrelationId: errors.CorrelationID(err),
+	}
+	if t := errors.Time(err); !t.IsZero() {
+		pb.OccurredAt, _ = ptypes.TimestampProto(t)
+	}
+	if meta := errors.Meta(err); len(meta) > 0 {
+		pb.Metadata = meta
+	}
+
+	if f, ok := errors.AsBadRequest(err); ok {
+		for _, v := range f.Violations {
+			pb.FieldViolations = append(pb.FieldViolations, &FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+				Reason:      v.Reason,
+				Expected:    v.Expected,
+				Actual:      v.Actual,
+			})
+		}
+	}
+	if f, ok := errors.AsPreconditionFailure(err); ok {
+		for _, v := range f.Violations {
+			pb.PreconditionViolations = append(pb.PreconditionViolations, &PreconditionViolation{
+				Type:        v.Type,
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+	}
+	if f, ok := errors.AsConflictFailure(err); ok {
+		for _, v := range f.Violations {
+			pb.ConflictViolations = append(pb.ConflictViolations, &ConflictViolation{
+				Resource:    v.Resource,
+				Description: v.Description,
+			})
+		}
+	}
+	if f, ok := errors.AsQuotaFailure(err); ok {
+		for _, v := range f.Violations {
+			violation := &QuotaViolation{
+				Subject:     v.Subject,
+				Description: v.Description,
+				Limit:       v.Limit,
+				Current:     v.Current,
+			}
+			if !v.ResetTime.IsZero() {
+				violation.ResetTime, _ = ptypes.TimestampProto(v.ResetTime)
+			}
+			pb.QuotaViolations = append(pb.QuotaViolations, violation)
+		}
+	}
+	if f, ok := errors.AsOutOfRangeFailure(err); ok {
+		for _, v := range f.Violations {
+			pb.RangeViolations = append(pb.RangeViolations, &RangeViolation{
+				Field:  v.Field,
+				Min:    v.Min,
+				Max:    v.Max,
+				Actual: v.Actual,
+			})
+		}
+	}
+	if f, ok := errors.AsExistsFailure(err); ok {
+		pb.Resource = f.Resource
+	}
+	if f, ok := errors.AsAvailabilityFailure(err); ok {
+		pb.RetryInfo = retryInfoToProto(&f.RetryInfo)
+		if f.Reason != errors.ReasonUnspecified {
+			pb.AvailabilityReason = f.Reason.String()
+		}
+	}
+	if f, ok := errors.AsDataLossFailure(err); ok && f.DebugInfo != nil {
+		pb.DebugInfo = &DebugInfo{
+			StackEntries: f.DebugInfo.StackEntries,
+			Detail:       f.DebugInfo.Detail,
+		}
+	}
+	if f, ok := errors.AsInternalFailure(err); ok && f.DebugInfo != nil {
+		pb.DebugInfo = &DebugInfo{
+			StackEntries: f.DebugInfo.StackEntries,
+			Detail:       f.DebugInfo.Detail,
+		}
+	}
+
+	for _, d := range errors.Details(err) {
+		switch d := d.(type) {
+		case *errors.RetryInfo:
+			if pb.RetryInfo == nil {
+				pb.RetryInfo = retryInfoToProto(d)
+			}
+		case *errors.DebugInfo:
+			if pb.DebugInfo == nil {
+				pb.DebugInfo = &DebugInfo{
+					StackEntries: d.StackEntries,
+					Detail:       d.Detail,
+				}
+			}
+		case *errors.LocalisedMessage:
+			pb.LocalizedMessages = append(pb.LocalizedMessages, &LocalizedMessage{
+				Locale:  d.Locale.String(),
+				Message: d.Message,
+			})
+		}
+	}
+	return pb
+}
+
+// FromProto reconstructs an error from its canonical protobuf
+// representation. It returns nil when pb is nil or carries Code_OK.
+func FromProto(pb *Error) error {
+	if pb == nil || pb.Code == Code_OK && len(pb.Causes) == 0 {
+		return nil
+	}
+
+	if len(pb.Causes) > 0 {
+		members := make([]error, len(pb.Causes))
+		for i, cause := range pb.Causes {
+			members[i] = FromProto(cause)
+		}
+		return errors.Join(members...)
+	}
+
+	var err error
+	switch pb.Code {
+	case Code_CANCELLED:
+		err = errors.WithCanceled(errors.New(pb.Message))
+	case Code_DEADLINE_EXCEEDED:
+		err = errors.WithDeadlineExceeded(errors.New(pb.Message))
+	case Code_NOT_FOUND:
+		err = errors.NotFound
+	case Code_ALREADY_EXISTS:
+		err = errors.AlreadyExists(pb.Resource)
+	case Code_PERMISSION_DENIED:
+		err = errors.PermissionDenied
+	case Code_UNAUTHENTICATED:
+		err = errors.Unauthenticated
+	case Code_INVALID_ARGUMENT:
+		violations := make([]errors.Option, len(pb.FieldViolations))
+		for i, v := range pb.FieldViolations {
+			violations[i] = &errors.FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+				Reason:      v.Reason,
+				Expected:    v.Expected,
+				Actual:      v.Actual,
+			}
+		}
+		err = errors.Bad(violations...)
+	case Code_FAILED_PRECONDITION:
+		violations := make([]errors.Option, len(pb.PreconditionViolations))
+		for i, v := range pb.PreconditionViolations {
+			violations[i] = &errors.PreconditionViolation{
+				Type:        v.Type,
+				Subject:     v.Subject,
+				Description: v.Description,
+			}
+		}
+		err = errors.FailedPrecondition(violations...)
+	case Code_ABORTED:
+		violations := make([]errors.Option, len(pb.ConflictViolations))
+		for i, v := range pb.ConflictViolations {
+			violations[i] = &errors.ConflictViolation{
+				Resource:    v.Resource,
+				Description: v.Description,
+			}
+		}
+		err = errors.Aborted(violations...)
+	case Code_RESOURCE_EXHAUSTED:
+		violations := make([]errors.Option, len(pb.QuotaViolations))
+		for i, v := range pb.QuotaViolations {
+			violation := &errors.QuotaViolation{
+				Subject:     v.Subject,
+				Description: v.Description,
+				Limit:       v.Limit,
+				Current:     v.Current,
+			}
+			if v.ResetTime != nil {
+				violation.ResetTime, _ = ptypes.Timestamp(v.ResetTime)
+			}
+			violations[i] = violation
+		}
+		err = errors.ResourceExhausted(violations...)
+	case Code_OUT_OF_RANGE:
+		violations := make([]errors.Option, len(pb.RangeViolations))
+		for i, v := range pb.RangeViolations {
+			violations[i] = &errors.RangeViolation{
+				Field:  v.Field,
+				Min:    v.Min,
+				Max:    v.Max,
+				Actual: v.Actual,
+			}
+		}
+		err = errors.OutOfRange(violations...)
+	case Code_UNIMPLEMENTED:
+		err = errors.WithNotImplemented(errors.New(pb.Message))
+	case Code_UNAVAILABLE:
+		var delay time.Duration
+		if pb.RetryInfo != nil && pb.RetryInfo.RetryDelay != nil {
+			delay, _ = ptypes.Duration(pb.RetryInfo.RetryDelay)
+		}
+		err = errors.UnavailableBecause(
+			errors.ParseAvailabilityReason(pb.AvailabilityReason), delay,
+		)
+	case Code_INTERNAL:
+		f := &errors.InternalFailure{}
+		if pb.DebugInfo != nil {
+			f.DebugInfo = &errors.DebugInfo{
+				StackEntries: pb.DebugInfo.StackEntries,
+				Detail:       pb.DebugInfo.Detail,
+			}
+		}
+		err = f
+	case Code_DATA_LOSS:
+		if pb.DebugInfo == nil {
+			err = errors.DataLoss
+		} else {
+			err = &errors.DataLossFailure{
+				DebugInfo: &errors.DebugInfo{
+					StackEntries: pb.DebugInfo.StackEntries,
+					Detail:       pb.DebugInfo.Detail,
+				},
+			}
+		}
+	default:
+		err = errors.New(pb.Message)
+	}
+
+	// Check the sentinel before attaching details, because the decorated
+	// error would hide it and let WithTime mutate the shared sentinel
+	sentinel := isSentinel(err)
+
+	var details []interface{}
+	if pb.CorrelationId != "" {
+		details = append(details, &errors.Correlation{ID: pb.CorrelationId})
+	}
+	if len(pb.Metadata) > 0 {
+		details = append(details, errors.Metadata(pb.Metadata))
+	}
+	for _, m := range pb.LocalizedMessages {
+		tag, parseErr := language.Parse(m.Locale)
+		if parseErr != nil {
+			continue
+		}
+		details = append(details, &errors.LocalisedMessage{
+			Locale:  tag,
+			Message: m.Message,
+		})
+	}
+	if len(details) > 0 {
+		err = errors.WithDetails(err, details...)
+	}
+
+	if pb.OccurredAt != nil && !sentinel {
+		if t, tsErr := ptypes.Timestamp(pb.OccurredAt); tsErr == nil {
+			err = errors.WithTime(err, t)
+		}
+	}
+	return err
+}
+
+func retryInfoToProto(info *errors.RetryInfo) *RetryInfo {
+	pb := &RetryInfo{
+		RetryDelay: ptypes.DurationProto(info.RetryDelay),
+		Jitter:     info.Jitter,
+	}
+	if !info.RetryAt.IsZero() {
+		pb.RetryAt, _ = ptypes.TimestampProto(info.RetryAt)
+	}
+	return pb
+}
+
+// isSentinel reports whether err is one of the shared sentinel errors, which
+// must never be mutated
+func isSentinel(err error) bool {
+	switch err {
+	case errors.NotFound, errors.PermissionDenied, errors.Unauthenticated, errors.DataLoss:
+		return true
+	}
+	return false
+}
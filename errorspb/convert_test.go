@@ -0,0 +1,154 @@
+package errorspb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/errorspb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestProto_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "bad request with violations",
+			err: errors.Bad(&errors.FieldViolation{
+				Field:       "email",
+				Description: "invalid email address",
+				Reason:      "EMAIL",
+			}),
+		},
+		{
+			name: "quota failure",
+			err: errors.ResourceExhausted(&errors.QuotaViolation{
+				Subject:     "api-calls",
+				Description: "rate limit exceeded",
+				Limit:       100,
+				Current:     101,
+			}),
+		},
+		{
+			name: "unavailable with reason",
+			err:  errors.UnavailableBecause(errors.ReasonMaintenance, 30*time.Second),
+		},
+		{
+			name: "not found sentinel",
+			err:  errors.NotFound,
+		},
+		{
+			name: "already exists",
+			err:  errors.AlreadyExists("user:42"),
+		},
+		{
+			name: "not implemented",
+			err:  errors.WithNotImplemented(errors.New("no such method")),
+		},
+		{
+			name: "multi failure",
+			err: errors.Join(
+				errors.Bad(&errors.FieldViolation{Field: "a", Description: "missing"}),
+				errors.Aborted(),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pb := errorspb.ToProto(test.err)
+
+			// The wire model must survive protobuf serialisation
+			data, err := proto.Marshal(pb)
+			if err != nil {
+				t.Fatalf("expect Marshal to succeed, but got error %v", err)
+			}
+			decoded := &errorspb.Error{}
+			if err := proto.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("expect Unmarshal to succeed, but got error %v", err)
+			}
+
+			got := errorspb.FromProto(decoded)
+			if !errors.Match(got, test.err) {
+				t.Errorf("expect errors to match after round trip, but got %v", got)
+			}
+		})
+	}
+}
+
+func TestProto_SentinelNotMutated(t *testing.T) {
+	pb := &errorspb.Error{
+		Code:          errorspb.Code_NOT_FOUND,
+		Message:       "not found",
+		CorrelationId: "req-1",
+	}
+	pb.OccurredAt, _ = ptypes.TimestampProto(time.Now())
+
+	got := errorspb.FromProto(pb)
+	if !errors.IsNotFound(got) {
+		t.Fatalf("expect a not found error, but got %v", got)
+	}
+	if id := errors.CorrelationID(got); id != "req-1" {
+		t.Errorf("expect correlation ID req-1, but got %s", id)
+	}
+	// The timestamp must be dropped rather than set on the shared sentinel
+	if !errors.Time(errors.NotFound).IsZero() {
+		t.Errorf("expect shared sentinel to keep a zero time, but got %v",
+			errors.Time(errors.NotFound),
+		)
+	}
+}
+
+func TestProto_AlreadyExists(t *testing.T) {
+	sent := errors.AlreadyExists("user:42")
+
+	got := errorspb.FromProto(errorspb.ToProto(sent))
+	f, ok := errors.AsExistsFailure(got)
+	if !ok {
+		t.Fatalf("expect an exists failure, but got %v", got)
+	}
+	if f.Resource != "user:42" {
+		t.Errorf("expect resource user:42, but got %q", f.Resource)
+	}
+	if !errors.Time(got).Equal(errors.Time(sent)) {
+		t.Errorf("expect creation time %v, but got %v",
+			errors.Time(sent), errors.Time(got),
+		)
+	}
+}
+
+func TestProto_Internal(t *testing.T) {
+	sent := &errors.InternalFailure{
+		DebugInfo: &errors.DebugInfo{
+			StackEntries: []string{"main.go:42"},
+			Detail:       "nil pointer dereference",
+		},
+	}
+
+	got := errorspb.FromProto(errorspb.ToProto(sent))
+	f, ok := errors.AsInternalFailure(got)
+	if !ok {
+		t.Fatalf("expect an internal failure, but got %v", got)
+	}
+	if f.DebugInfo == nil {
+		t.Fatal("expect the debug info to survive, but got nil")
+	}
+	if f.DebugInfo.Detail != "nil pointer dereference" {
+		t.Errorf("expect the debug detail, but got %q", f.DebugInfo.Detail)
+	}
+	if len(f.DebugInfo.StackEntries) != 1 || f.DebugInfo.StackEntries[0] != "main.go:42" {
+		t.Errorf("expect the stack entries, but got %v", f.DebugInfo.StackEntries)
+	}
+}
+
+func TestProto_Nil(t *testing.T) {
+	if pb := errorspb.ToProto(nil); pb != nil {
+		t.Errorf("expect nil proto, but got %v", pb)
+	}
+	if err := errorspb.FromProto(nil); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
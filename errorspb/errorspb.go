@@ -0,0 +1,209 @@
+// Package errorspb carries the canonical protobuf wire model of the errors
+// package, so non-Go services and event pipelines can consume errors
+// natively rather than via the lossy google.rpc mapping.
+//
+// The Go types mirror errors.proto and are maintained by hand until protoc
+// is wired into the build. Field numbers must stay in sync with the schema.
+package errorspb
+
+import (
+	"github.com/golang/protobuf/proto"
+	dpb "github.com/golang/protobuf/ptypes/duration"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// Code mirrors the canonical error codes of the errors package.
+type Code int32
+
+const (
+	Code_OK                  Code = 0
+	Code_CANCELLED           Code = 1
+	Code_UNKNOWN             Code = 2
+	Code_INVALID_ARGUMENT    Code = 3
+	Code_DEADLINE_EXCEEDED   Code = 4
+	Code_NOT_FOUND           Code = 5
+	Code_ALREADY_EXISTS      Code = 6
+	Code_PERMISSION_DENIED   Code = 7
+	Code_RESOURCE_EXHAUSTED  Code = 8
+	Code_FAILED_PRECONDITION Code = 9
+	Code_ABORTED             Code = 10
+	Code_OUT_OF_RANGE        Code = 11
+	Code_UNIMPLEMENTED       Code = 12
+	Code_INTERNAL            Code = 13
+	Code_UNAVAILABLE         Code = 14
+	Code_DATA_LOSS           Code = 15
+	Code_UNAUTHENTICATED     Code = 16
+)
+
+var Code_name = map[int32]string{
+	0:  "OK",
+	1:  "CANCELLED",
+	2:  "UNKNOWN",
+	3:  "INVALID_ARGUMENT",
+	4:  "DEADLINE_EXCEEDED",
+	5:  "NOT_FOUND",
+	6:  "ALREADY_EXISTS",
+	7:  "PERMISSION_DENIED",
+	8:  "RESOURCE_EXHAUSTED",
+	9:  "FAILED_PRECONDITION",
+	10: "ABORTED",
+	11: "OUT_OF_RANGE",
+	12: "UNIMPLEMENTED",
+	13: "INTERNAL",
+	14: "UNAVAILABLE",
+	15: "DATA_LOSS",
+	16: "UNAUTHENTICATED",
+}
+
+var Code_value = map[string]int32{
+	"OK":                  0,
+	"CANCELLED":           1,
+	"UNKNOWN":             2,
+	"INVALID_ARGUMENT":    3,
+	"DEADLINE_EXCEEDED":   4,
+	"NOT_FOUND":           5,
+	"ALREADY_EXISTS":      6,
+	"PERMISSION_DENIED":   7,
+	"RESOURCE_EXHAUSTED":  8,
+	"FAILED_PRECONDITION": 9,
+	"ABORTED":             10,
+	"OUT_OF_RANGE":        11,
+	"UNIMPLEMENTED":       12,
+	"INTERNAL":            13,
+	"UNAVAILABLE":         14,
+	"DATA_LOSS":           15,
+	"UNAUTHENTICATED":     16,
+}
+
+func (x Code) String() string {
+	return proto.EnumName(Code_name, int32(x))
+}
+
+// Error is the canonical wire representation of an error produced by the
+// errors package.
+type Error struct {
+	Code                   Code                     `protobuf:"varint,1,opt,name=code,proto3,enum=deixis.errors.v1.Code" json:"code,omitempty"`
+	Message                string                   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	OccurredAt             *tspb.Timestamp          `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	FieldViolations        []*FieldViolation        `protobuf:"bytes,4,rep,name=field_violations,json=fieldViolations,proto3" json:"field_violations,omitempty"`
+	PreconditionViolations []*PreconditionViolation `protobuf:"bytes,5,rep,name=precondition_violations,json=preconditionViolations,proto3" json:"precondition_violations,omitempty"`
+	ConflictViolations     []*ConflictViolation     `protobuf:"bytes,6,rep,name=conflict_violations,json=conflictViolations,proto3" json:"conflict_violations,omitempty"`
+	QuotaViolations        []*QuotaViolation        `protobuf:"bytes,7,rep,name=quota_violations,json=quotaViolations,proto3" json:"quota_violations,omitempty"`
+	RangeViolations        []*RangeViolation        `protobuf:"bytes,8,rep,name=range_violations,json=rangeViolations,proto3" json:"range_violations,omitempty"`
+	RetryInfo              *RetryInfo               `protobuf:"bytes,9,opt,name=retry_info,json=retryInfo,proto3" json:"retry_info,omitempty"`
+	DebugInfo              *DebugInfo               `protobuf:"bytes,10,opt,name=debug_info,json=debugInfo,proto3" json:"debug_info,omitempty"`
+	LocalizedMessages      []*LocalizedMessage      `protobuf:"bytes,11,rep,name=localized_messages,json=localizedMessages,proto3" json:"localized_messages,omitempty"`
+	Metadata               map[string]string        `protobuf:"bytes,12,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CorrelationId          string                   `protobuf:"bytes,13,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	AvailabilityReason     string                   `protobuf:"bytes,14,opt,name=availability_reason,json=availabilityReason,proto3" json:"availability_reason,omitempty"`
+	Causes                 []*Error                 `protobuf:"bytes,15,rep,name=causes,proto3" json:"causes,omitempty"`
+	Resource               string                   `protobuf:"bytes,16,opt,name=resource,proto3" json:"resource,omitempty"`
+}
+
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+
+// A message type used to describe a single bad request field.
+type FieldViolation struct {
+	Field       string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Reason      string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Expected    string `protobuf:"bytes,4,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual      string `protobuf:"bytes,5,opt,name=actual,proto3" json:"actual,omitempty"`
+}
+
+func (m *FieldViolation) Reset()         { *m = FieldViolation{} }
+func (m *FieldViolation) String() string { return proto.CompactTextString(m) }
+func (*FieldViolation) ProtoMessage()    {}
+
+// A message type used to describe a single precondition failure.
+type PreconditionViolation struct {
+	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Subject     string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (m *PreconditionViolation) Reset()         { *m = PreconditionViolation{} }
+func (m *PreconditionViolation) String() string { return proto.CompactTextString(m) }
+func (*PreconditionViolation) ProtoMessage()    {}
+
+// A message type used to describe a single conflict.
+type ConflictViolation struct {
+	Resource    string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (m *ConflictViolation) Reset()         { *m = ConflictViolation{} }
+func (m *ConflictViolation) String() string { return proto.CompactTextString(m) }
+func (*ConflictViolation) ProtoMessage()    {}
+
+// A message type used to describe a single quota violation.
+type QuotaViolation struct {
+	Subject     string          `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Description string          `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Limit       int64           `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Current     int64           `protobuf:"varint,4,opt,name=current,proto3" json:"current,omitempty"`
+	ResetTime   *tspb.Timestamp `protobuf:"bytes,5,opt,name=reset_time,json=resetTime,proto3" json:"reset_time,omitempty"`
+}
+
+func (m *QuotaViolation) Reset()         { *m = QuotaViolation{} }
+func (m *QuotaViolation) String() string { return proto.CompactTextString(m) }
+func (*QuotaViolation) ProtoMessage()    {}
+
+// A message type used to describe a single out of range violation.
+type RangeViolation struct {
+	Field  string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Min    int64  `protobuf:"varint,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max    int64  `protobuf:"varint,3,opt,name=max,proto3" json:"max,omitempty"`
+	Actual int64  `protobuf:"varint,4,opt,name=actual,proto3" json:"actual,omitempty"`
+}
+
+func (m *RangeViolation) Reset()         { *m = RangeViolation{} }
+func (m *RangeViolation) String() string { return proto.CompactTextString(m) }
+func (*RangeViolation) ProtoMessage()    {}
+
+// Describes when clients can retry a failed request.
+type RetryInfo struct {
+	RetryDelay *dpb.Duration   `protobuf:"bytes,1,opt,name=retry_delay,json=retryDelay,proto3" json:"retry_delay,omitempty"`
+	RetryAt    *tspb.Timestamp `protobuf:"bytes,2,opt,name=retry_at,json=retryAt,proto3" json:"retry_at,omitempty"`
+	Jitter     float64         `protobuf:"fixed64,3,opt,name=jitter,proto3" json:"jitter,omitempty"`
+}
+
+func (m *RetryInfo) Reset()         { *m = RetryInfo{} }
+func (m *RetryInfo) String() string { return proto.CompactTextString(m) }
+func (*RetryInfo) ProtoMessage()    {}
+
+// Describes additional debugging info.
+type DebugInfo struct {
+	StackEntries []string `protobuf:"bytes,1,rep,name=stack_entries,json=stackEntries,proto3" json:"stack_entries,omitempty"`
+	Detail       string   `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (m *DebugInfo) Reset()         { *m = DebugInfo{} }
+func (m *DebugInfo) String() string { return proto.CompactTextString(m) }
+func (*DebugInfo) ProtoMessage()    {}
+
+// Provides a localized error message that is safe to return to the user.
+type LocalizedMessage struct {
+	Locale  string `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *LocalizedMessage) Reset()         { *m = LocalizedMessage{} }
+func (m *LocalizedMessage) String() string { return proto.CompactTextString(m) }
+func (*LocalizedMessage) ProtoMessage()    {}
+
+func init() {
+	proto.RegisterEnum("deixis.errors.v1.Code", Code_name, Code_value)
+	proto.RegisterType((*Error)(nil), "deixis.errors.v1.Error")
+	proto.RegisterMapType((map[string]string)(nil), "deixis.errors.v1.Error.MetadataEntry")
+	proto.RegisterType((*FieldViolation)(nil), "deixis.errors.v1.FieldViolation")
+	proto.RegisterType((*PreconditionViolation)(nil), "deixis.errors.v1.PreconditionViolation")
+	proto.RegisterType((*ConflictViolation)(nil), "deixis.errors.v1.ConflictViolation")
+	proto.RegisterType((*QuotaViolation)(nil), "deixis.errors.v1.QuotaViolation")
+	proto.RegisterType((*RangeViolation)(nil), "deixis.errors.v1.RangeViolation")
+	proto.RegisterType((*RetryInfo)(nil), "deixis.errors.v1.RetryInfo")
+	proto.RegisterType((*DebugInfo)(nil), "deixis.errors.v1.DebugInfo")
+	proto.RegisterType((*LocalizedMessage)(nil), "deixis.errors.v1.LocalizedMessage")
+}
@@ -0,0 +1,159 @@
+// Package errortest provides assertion helpers and in-process transport
+// harnesses for testing services built on this module. The harnesses
+// roundtrip an error through the real gRPC or HTTP stack and diff the
+// result against the original, so transport regressions show up in the
+// service's own test suite.
+package errortest
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"github.com/deixis/errors/httperrors"
+)
+
+// AssertCode fails t when err does not carry the canonical code want
+func AssertCode(t testing.TB, err error, want errors.Code) {
+	t.Helper()
+	if got := errors.CodeOf(err); got != want {
+		t.Errorf("expect code %v, but got %v", want, got)
+	}
+}
+
+// AssertViolations fails t when the violations carried by got differ from
+// those carried by want, ignoring order
+func AssertViolations(t testing.TB, got, want error) {
+	t.Helper()
+	g, w := violationSet(got), violationSet(want)
+	if len(g) != len(w) {
+		t.Errorf("expect violations %v, but got %v", w, g)
+		return
+	}
+	for i := range g {
+		if g[i] != w[i] {
+			t.Errorf("expect violations %v, but got %v", w, g)
+			return
+		}
+	}
+}
+
+// AssertMatch fails t when got does not match want (see errors.Match)
+func AssertMatch(t testing.TB, got, want error) {
+	t.Helper()
+	if !errors.Match(got, want) {
+		t.Errorf("expect %v, but got %v", want, got)
+	}
+}
+
+// violationSet returns the sorted string representation of the violations
+// carried by err
+func violationSet(err error) []string {
+	var s []string
+	if f, ok := errors.AsBadRequest(err); ok {
+		for _, v := range f.Violations {
+			s = append(s, v.String())
+		}
+	}
+	if f, ok := errors.AsPreconditionFailure(err); ok {
+		for _, v := range f.Violations {
+			s = append(s, v.String())
+		}
+	}
+	if f, ok := errors.AsConflictFailure(err); ok {
+		for _, v := range f.Violations {
+			s = append(s, v.String())
+		}
+	}
+	if f, ok := errors.AsQuotaFailure(err); ok {
+		for _, v := range f.Violations {
+			s = append(s, v.String())
+		}
+	}
+	if f, ok := errors.AsOutOfRangeFailure(err); ok {
+		for _, v := range f.Violations {
+			s = append(s, v.String())
+		}
+	}
+	sort.Strings(s)
+	return s
+}
+
+// RoundTripGRPC sends err through an in-process gRPC call, unpacks the
+// result and fails t when it does not match the original. The unpacked
+// error is returned for further assertions.
+func RoundTripGRPC(t testing.TB, err error) error {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(
+		func(interface{}, grpc.ServerStream) error {
+			return grpcerrors.Pack(err).Err()
+		},
+	))
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, derr := grpc.DialContext(ctx, "bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if derr != nil {
+		t.Fatalf("expect to dial the in-process server, but got %v", derr)
+	}
+	defer conn.Close()
+
+	rerr := conn.Invoke(ctx, "/errortest.Harness/RoundTrip",
+		&structpb.Struct{}, &structpb.Struct{},
+	)
+	if rerr == nil {
+		t.Fatal("expect the call to fail")
+	}
+	got := grpcerrors.Unpack(rerr)
+	if !errors.Match(got, err) {
+		t.Errorf("expect %v to survive the gRPC roundtrip, but got %v", err, got)
+	}
+	return got
+}
+
+// RoundTripHTTP marshals err through an in-process HTTP exchange,
+// unmarshals the response and fails t when it does not match the original.
+// The unmarshalled error is returned for further assertions.
+func RoundTripHTTP(t testing.TB, err error) error {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if merr := httperrors.Marshal(r, w, err); merr != nil {
+				t.Errorf("expect to marshal the error, but got %v", merr)
+			}
+		},
+	))
+	defer srv.Close()
+
+	res, herr := http.Get(srv.URL)
+	if herr != nil {
+		t.Fatalf("expect to reach the in-process server, but got %v", herr)
+	}
+	defer res.Body.Close()
+
+	got := httperrors.Unmarshal(res)
+	if !errors.Match(got, err) {
+		t.Errorf("expect %v to survive the HTTP roundtrip, but got %v", err, got)
+	}
+	return got
+}
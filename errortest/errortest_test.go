@@ -0,0 +1,34 @@
+package errortest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/errortest"
+)
+
+func TestRoundTripGRPC(t *testing.T) {
+	sent := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	})
+
+	got := errortest.RoundTripGRPC(t, sent)
+	errortest.AssertCode(t, got, errors.CodeInvalidArgument)
+	errortest.AssertViolations(t, got, sent)
+}
+
+func TestRoundTripHTTP(t *testing.T) {
+	sent := errors.ResourceExhausted(
+		&errors.QuotaViolation{
+			Subject:     "project:x/read",
+			Description: "Daily limit exceeded",
+		},
+		errors.WithRetryInfo(time.Minute),
+	)
+
+	got := errortest.RoundTripHTTP(t, sent)
+	errortest.AssertCode(t, got, errors.CodeResourceExhausted)
+	errortest.AssertViolations(t, got, sent)
+}
@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FieldPath identifies a field in a request body, e.g. the second address
+// of a user. It renders either as a dot-separated proto path or as an
+// RFC 6901 JSON pointer, so hand-written nested paths stay consistent
+// across services.
+//
+//	errors.Path("user").Field("address").Index(2).String()      // user.address[2]
+//	errors.Path("user").Field("address").Index(2).JSONPointer() // /user/address/2
+type FieldPath struct {
+	segments []pathSegment
+}
+
+type pathSegment struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+// Path starts a field path at the given field
+func Path(field string) FieldPath {
+	return FieldPath{}.Field(field)
+}
+
+// Field returns a copy of the path descending into the given field
+func (p FieldPath) Field(name string) FieldPath {
+	segments := make([]pathSegment, len(p.segments), len(p.segments)+1)
+	copy(segments, p.segments)
+	return FieldPath{segments: append(segments, pathSegment{name: name})}
+}
+
+// Index returns a copy of the path descending into the i-th element of a
+// repeated field
+func (p FieldPath) Index(i int) FieldPath {
+	segments := make([]pathSegment, len(p.segments), len(p.segments)+1)
+	copy(segments, p.segments)
+	return FieldPath{segments: append(segments, pathSegment{index: i, isIndex: true})}
+}
+
+// String renders the path as a sequence of dot-separated identifiers, the
+// format used by protocol buffer field paths (e.g. "user.address[2].line")
+func (p FieldPath) String() string {
+	var b strings.Builder
+	for _, s := range p.segments {
+		if s.isIndex {
+			b.WriteString("[")
+			b.WriteString(strconv.Itoa(s.index))
+			b.WriteString("]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(s.name)
+	}
+	return b.String()
+}
+
+// JSONPointer renders the path as an RFC 6901 JSON pointer
+// (e.g. "/user/address/2/line")
+func (p FieldPath) JSONPointer() string {
+	var b strings.Builder
+	for _, s := range p.segments {
+		b.WriteString("/")
+		if s.isIndex {
+			b.WriteString(strconv.Itoa(s.index))
+			continue
+		}
+		b.WriteString(escapeJSONPointer(s.name))
+	}
+	return b.String()
+}
+
+// Violation returns a FieldViolation for the path with the given
+// description
+func (p FieldPath) Violation(description string) *FieldViolation {
+	return &FieldViolation{
+		Field:       p.String(),
+		Description: description,
+	}
+}
+
+// escapeJSONPointer escapes the RFC 6901 special characters
+func escapeJSONPointer(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	return strings.ReplaceAll(s, "/", "~1")
+}
@@ -0,0 +1,83 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestFieldPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    errors.FieldPath
+		str     string
+		pointer string
+	}{
+		{
+			name:    "nested with index",
+			path:    errors.Path("user").Field("address").Index(2).Field("line"),
+			str:     "user.address[2].line",
+			pointer: "/user/address/2/line",
+		},
+		{
+			name:    "single field",
+			path:    errors.Path("email"),
+			str:     "email",
+			pointer: "/email",
+		},
+		{
+			name:    "index first",
+			path:    errors.FieldPath{}.Index(0).Field("id"),
+			str:     "[0].id",
+			pointer: "/0/id",
+		},
+		{
+			name:    "empty segment",
+			path:    errors.Path(""),
+			str:     "",
+			pointer: "/",
+		},
+		{
+			// RFC 6901 escapes ~ and / in reference tokens
+			name:    "escaped pointer",
+			path:    errors.Path("a/b").Field("c~d"),
+			str:     "a/b.c~d",
+			pointer: "/a~1b/c~0d",
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.path.String(); got != test.str {
+			t.Errorf("%s: expect %q, but got %q", test.name, test.str, got)
+		}
+		if got := test.path.JSONPointer(); got != test.pointer {
+			t.Errorf("%s: expect pointer %q, but got %q", test.name, test.pointer, got)
+		}
+	}
+}
+
+func TestFieldPath_CopyOnWrite(t *testing.T) {
+	base := errors.Path("user").Field("address")
+	home := base.Field("home")
+	work := base.Field("work")
+
+	if got := base.String(); got != "user.address" {
+		t.Errorf("expect the base path to be untouched, but got %q", got)
+	}
+	if got := home.String(); got != "user.address.home" {
+		t.Errorf("expect user.address.home, but got %q", got)
+	}
+	if got := work.String(); got != "user.address.work" {
+		t.Errorf("expect user.address.work, but got %q", got)
+	}
+}
+
+func TestFieldPath_Violation(t *testing.T) {
+	v := errors.Path("user").Field("email").Violation("missing")
+	if v.Field != "user.email" {
+		t.Errorf("expect field user.email, but got %q", v.Field)
+	}
+	if v.Description != "missing" {
+		t.Errorf("expect description missing, but got %q", v.Description)
+	}
+}
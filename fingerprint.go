@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Fingerprint produces a stable hash of the error shape — its code,
+// machine-readable reasons, violation fields and the innermost cause type —
+// for use by error reporters and alert deduplication. Descriptions and wrap
+// messages are deliberately excluded, because grouping on free-form
+// messages explodes cardinality.
+//
+// It returns an empty string when err is nil.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fingerprintParts(err), "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func fingerprintParts(err error) []string {
+	if multi, ok := AsMultiFailure(err); ok {
+		var parts []string
+		for _, member := range multi.Errors {
+			parts = append(parts, fingerprintParts(member)...)
+		}
+		sort.Strings(parts)
+		return append([]string{CodeOf(err).String()}, parts...)
+	}
+
+	parts := []string{CodeOf(err).String()}
+
+	var shape []string
+	if f, ok := AsAvailabilityFailure(err); ok && f.Reason != ReasonUnspecified {
+		shape = append(shape, f.Reason.String())
+	}
+	if f, ok := AsBadRequest(err); ok {
+		for _, v := range f.Violations {
+			shape = append(shape, strings.Join([]string{v.Field, v.Reason}, ":"))
+		}
+	}
+	if f, ok := AsPreconditionFailure(err); ok {
+		for _, v := range f.Violations {
+			shape = append(shape, strings.Join([]string{v.Type, v.Subject}, ":"))
+		}
+	}
+	if f, ok := AsConflictFailure(err); ok {
+		for _, v := range f.Violations {
+			shape = append(shape, v.Resource)
+		}
+	}
+	if f, ok := AsQuotaFailure(err); ok {
+		for _, v := range f.Violations {
+			shape = append(shape, v.Subject)
+		}
+	}
+	if f, ok := AsOutOfRangeFailure(err); ok {
+		for _, v := range f.Violations {
+			shape = append(shape, v.Field)
+		}
+	}
+	// Violations are sorted, so the fingerprint does not depend on the
+	// order in which they were attached
+	sort.Strings(shape)
+	parts = append(parts, shape...)
+
+	return append(parts, fmt.Sprintf("%T", Root(err)))
+}
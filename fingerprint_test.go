@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestFingerprint(t *testing.T) {
+	a := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Reason:      "EMAIL",
+		Description: "jane@example.org is not a valid email",
+	})
+	b := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Reason:      "EMAIL",
+		Description: "john@example.net is not a valid email",
+	})
+	c := errors.Bad(&errors.FieldViolation{
+		Field:       "age",
+		Reason:      "REQUIRED",
+		Description: "age is required",
+	})
+
+	if errors.Fingerprint(a) != errors.Fingerprint(b) {
+		t.Error("expect the same fingerprint for errors differing only by description")
+	}
+	if errors.Fingerprint(a) == errors.Fingerprint(c) {
+		t.Error("expect different fingerprints for different violation fields")
+	}
+}
+
+func TestFingerprint_CauseType(t *testing.T) {
+	a := errors.WithNotFound(errors.New("user 42 does not exist"))
+	b := errors.WithNotFound(errors.New("user 43 does not exist"))
+	c := errors.WithPermissionDenied(errors.New("user 42 does not exist"))
+
+	if errors.Fingerprint(a) != errors.Fingerprint(b) {
+		t.Error("expect the same fingerprint for errors differing only by message")
+	}
+	if errors.Fingerprint(a) == errors.Fingerprint(c) {
+		t.Error("expect different fingerprints for different codes")
+	}
+}
+
+func TestFingerprint_Nil(t *testing.T) {
+	if got := errors.Fingerprint(nil); got != "" {
+		t.Errorf("expect empty fingerprint, but got %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"database/sql"
+	"net"
+	"sync"
+	"time"
+)
+
+// SQLRetryDelay is the retry delay attached to failures mapped from driver
+// timeouts, for which the driver gives no hint of when to retry.
+var SQLRetryDelay = time.Second
+
+// SQLClassifier classifies a driver-specific error into a typed failure. It
+// reports false when the error is not recognised, in which case the next
+// classifier is consulted (see RegisterSQLClassifier).
+type SQLClassifier func(err error) (error, bool)
+
+var sqlClassifiers = struct {
+	mu          sync.RWMutex
+	classifiers []SQLClassifier
+}{}
+
+// RegisterSQLClassifier declares a driver-specific error classifier
+// consulted by FromSQL before the generic database/sql mapping. It is
+// expected to be called at init time, e.g. by importing errors/sqlclass.
+func RegisterSQLClassifier(c SQLClassifier) {
+	sqlClassifiers.mu.Lock()
+	defer sqlClassifiers.mu.Unlock()
+
+	sqlClassifiers.classifiers = append(sqlClassifiers.classifiers, c)
+}
+
+// FromSQL classifies database/sql errors into typed failures, keeping the
+// original error as the cause:
+//
+//   - sql.ErrNoRows becomes a MissingFailure
+//   - sql.ErrTxDone and sql.ErrConnDone become a ConflictFailure
+//   - driver timeouts become an AvailabilityFailure with a retry delay
+//
+// Driver-specific classifiers registered with RegisterSQLClassifier take
+// precedence, so constraint violations can be mapped by driver (see
+// errors/sqlclass). Errors that cannot be classified are returned
+// unchanged. If err is nil, FromSQL returns nil.
+func FromSQL(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	sqlClassifiers.mu.RLock()
+	classifiers := sqlClassifiers.classifiers
+	sqlClassifiers.mu.RUnlock()
+	for _, c := range classifiers {
+		if mapped, ok := c(err); ok {
+			return mapped
+		}
+	}
+
+	switch {
+	case Is(err, sql.ErrNoRows):
+		return WithNotFound(err)
+	case Is(err, sql.ErrTxDone), Is(err, sql.ErrConnDone):
+		return WithAborted(err)
+	}
+
+	var netErr net.Error
+	if As(err, &netErr) && netErr.Timeout() {
+		return WithUnavailable(err, SQLRetryDelay)
+	}
+	return err
+}
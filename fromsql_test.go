@@ -0,0 +1,60 @@
+package errors_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestFromSQL(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expect errors.Code
+	}{
+		{name: "no rows", err: sql.ErrNoRows, expect: errors.CodeNotFound},
+		{name: "wrapped no rows", err: fmt.Errorf("load user: %w", sql.ErrNoRows), expect: errors.CodeNotFound},
+		{name: "tx done", err: sql.ErrTxDone, expect: errors.CodeAborted},
+		{name: "conn done", err: sql.ErrConnDone, expect: errors.CodeAborted},
+		{name: "timeout", err: timeoutError{}, expect: errors.CodeUnavailable},
+		{name: "unclassified", err: errors.New("boom"), expect: errors.CodeUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := errors.FromSQL(test.err)
+			if code := errors.CodeOf(got); code != test.expect {
+				t.Errorf("expect code %v, but got %v", test.expect, code)
+			}
+		})
+	}
+}
+
+func TestFromSQL_Classifier(t *testing.T) {
+	errors.RegisterSQLClassifier(func(err error) (error, bool) {
+		if err.Error() == "driver: special" {
+			return errors.Aborted(&errors.ConflictViolation{
+				Resource:    "users_email_key",
+				Description: "duplicate key",
+			}), true
+		}
+		return nil, false
+	})
+
+	got := errors.FromSQL(errors.New("driver: special"))
+	f, ok := errors.AsConflictFailure(got)
+	if !ok {
+		t.Fatalf("expect a ConflictFailure, but got %v", got)
+	}
+	if f.Violations[0].Resource != "users_email_key" {
+		t.Errorf("expect the classifier violation, but got %v", f.Violations)
+	}
+}
+
+func TestFromSQL_Nil(t *testing.T) {
+	if err := errors.FromSQL(nil); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"syscall"
+)
+
+// FromStd classifies common standard library errors into typed failures,
+// keeping the original error as the cause:
+//
+//   - os.ErrNotExist becomes a MissingFailure
+//   - os.ErrPermission becomes a PermissionFailure
+//   - io.EOF and io.ErrUnexpectedEOF become a DataLossFailure
+//   - context and net timeouts become a TimeoutFailure
+//   - connection-level syscall errors become an AvailabilityFailure
+//
+// Errors that cannot be classified are returned unchanged. If err is nil,
+// FromStd returns nil.
+func FromStd(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case Is(err, os.ErrNotExist):
+		return WithNotFound(err)
+	case Is(err, os.ErrPermission):
+		return WithPermissionDenied(err)
+	case Is(err, io.EOF), Is(err, io.ErrUnexpectedEOF):
+		return WithDataLoss(err)
+	case Is(err, context.DeadlineExceeded):
+		return WithDeadlineExceeded(err)
+	case Is(err, context.Canceled):
+		return WithCanceled(err)
+	}
+
+	var errno syscall.Errno
+	if As(err, &errno) {
+		switch errno {
+		case syscall.ECONNREFUSED, syscall.ECONNRESET, syscall.ECONNABORTED,
+			syscall.EPIPE, syscall.EHOSTUNREACH, syscall.ENETUNREACH,
+			syscall.ETIMEDOUT:
+			return WithUnavailable(err, 0)
+		}
+	}
+
+	var netErr net.Error
+	if As(err, &netErr) {
+		if netErr.Timeout() {
+			return WithDeadlineExceeded(err)
+		}
+		return WithUnavailable(err, 0)
+	}
+	return err
+}
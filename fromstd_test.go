@@ -0,0 +1,54 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestFromStd(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expect errors.Code
+	}{
+		{name: "not exist", err: os.ErrNotExist, expect: errors.CodeNotFound},
+		{name: "wrapped not exist", err: fmt.Errorf("read config: %w", os.ErrNotExist), expect: errors.CodeNotFound},
+		{name: "permission", err: os.ErrPermission, expect: errors.CodePermissionDenied},
+		{name: "eof", err: io.EOF, expect: errors.CodeDataLoss},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, expect: errors.CodeDataLoss},
+		{name: "connection refused", err: syscall.ECONNREFUSED, expect: errors.CodeUnavailable},
+		{name: "net op timeout", err: &net.OpError{Op: "dial", Err: timeoutError{}}, expect: errors.CodeDeadlineExceeded},
+		{name: "net op refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, expect: errors.CodeUnavailable},
+		{name: "unclassified", err: errors.New("boom"), expect: errors.CodeUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := errors.FromStd(test.err)
+			if code := errors.CodeOf(got); code != test.expect {
+				t.Errorf("expect code %v, but got %v", test.expect, code)
+			}
+			if !errors.Is(got, test.err) {
+				t.Errorf("expect the original error to be kept as cause")
+			}
+		})
+	}
+}
+
+func TestFromStd_Nil(t *testing.T) {
+	if err := errors.FromStd(nil); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
@@ -1,13 +1,39 @@
 module github.com/deixis/errors
 
-go 1.14
+go 1.19
 
 require (
+	connectrpc.com/connect v1.16.2
 	github.com/deixis/spine v0.1.2-0.20210714112119-1e0fb2dc4385
-	github.com/golang/protobuf v1.4.1
+	github.com/fxamacker/cbor/v2 v2.2.0
+	github.com/go-playground/validator/v10 v10.4.1
+	github.com/go-sql-driver/mysql v1.5.0
+	github.com/golang/protobuf v1.5.4
+	github.com/jackc/pgconn v1.8.1
+	github.com/lib/pq v1.10.2
 	github.com/pkg/errors v0.9.1
-	github.com/stretchr/objx v0.1.1 // indirect
-	golang.org/x/text v0.3.2
+	github.com/vmihailenco/msgpack/v4 v4.3.12
+	golang.org/x/text v0.14.0
 	google.golang.org/genproto v0.0.0-20191115221424-83cc0476cb11
 	google.golang.org/grpc v1.28.0
 )
+
+require (
+	github.com/go-playground/locales v0.13.0 // indirect
+	github.com/go-playground/universal-translator v0.17.0 // indirect
+	github.com/google/uuid v1.1.1 // indirect
+	github.com/jackc/chunkreader/v2 v2.0.1 // indirect
+	github.com/jackc/pgio v1.0.0 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgproto3/v2 v2.0.6 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20200714003250-2b9c44734f2b // indirect
+	github.com/leodido/go-urn v1.2.0 // indirect
+	github.com/opentracing/opentracing-go v1.1.0 // indirect
+	github.com/vmihailenco/tagparser v0.1.1 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/crypto v0.21.0 // indirect
+	golang.org/x/net v0.23.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	google.golang.org/appengine v1.6.5 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+)
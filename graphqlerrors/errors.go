@@ -0,0 +1,163 @@
+// Package graphqlerrors converts typed failures into GraphQL error objects
+// and back. The canonical code travels in extensions.code, field violations
+// become one error per field with its path, and retry hints are exposed in
+// extensions.retryAfterSeconds.
+package graphqlerrors
+
+import (
+	"strings"
+	"time"
+
+	"github.com/deixis/errors"
+)
+
+// Error is the GraphQL error object
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// From converts err into GraphQL error objects. A BadRequest produces one
+// error per field violation, with the field as path. Any other failure
+// produces a single error carrying the canonical code.
+func From(err error) []*Error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	if um := errors.UserMessage(err); um != "" {
+		msg = um
+	}
+
+	if f, ok := errors.AsBadRequest(err); ok && len(f.Violations) > 0 {
+		gqlErrs := make([]*Error, len(f.Violations))
+		for i, v := range f.Violations {
+			gqlErrs[i] = &Error{
+				Message:    v.Description,
+				Path:       fieldPath(v.Field),
+				Extensions: extensions(err),
+			}
+		}
+		return gqlErrs
+	}
+	return []*Error{{Message: msg, Extensions: extensions(err)}}
+}
+
+// ToError reconstructs a typed failure from the errors of a GraphQL client
+// response. Errors sharing the INVALID_ARGUMENT code merge back into a
+// single BadRequest.
+func ToError(gqlErrs []*Error) error {
+	if len(gqlErrs) == 0 {
+		return nil
+	}
+
+	var violations []errors.Option
+	for _, e := range gqlErrs {
+		if e.code() != "INVALID_ARGUMENT" {
+			continue
+		}
+		violations = append(violations, &errors.FieldViolation{
+			Field:       joinPath(e.Path),
+			Description: e.Message,
+		})
+	}
+	if len(violations) > 0 {
+		return errors.Bad(violations...)
+	}
+
+	first := gqlErrs[0]
+	switch first.code() {
+	case "NOT_FOUND":
+		return errors.NotFound
+	case "PERMISSION_DENIED":
+		return errors.PermissionDenied
+	case "UNAUTHENTICATED":
+		return errors.Unauthenticated
+	case "FAILED_PRECONDITION":
+		return errors.FailedPrecondition()
+	case "ABORTED", "ALREADY_EXISTS":
+		return errors.Aborted()
+	case "RESOURCE_EXHAUSTED":
+		if d := first.retryAfter(); d > 0 {
+			return errors.ResourceExhausted(errors.WithRetryInfo(d))
+		}
+		return errors.ResourceExhausted()
+	case "UNAVAILABLE":
+		return errors.Unavailable(first.retryAfter())
+	case "DEADLINE_EXCEEDED":
+		return errors.WithDeadlineExceeded(errors.New(first.Message))
+	case "CANCELLED":
+		return errors.WithCanceled(errors.New(first.Message))
+	case "OUT_OF_RANGE":
+		return errors.OutOfRange()
+	case "DATA_LOSS":
+		return errors.WithDataLoss(errors.New(first.Message))
+	}
+	return errors.New(first.Message)
+}
+
+// extensions builds the extensions object shared by the errors of err
+func extensions(err error) map[string]interface{} {
+	ext := map[string]interface{}{
+		"code": codeName(errors.CodeOf(err)),
+	}
+	if d := errors.RetryAfter(err); d > 0 {
+		ext["retryAfterSeconds"] = int(d.Seconds())
+	}
+	return ext
+}
+
+// codeName returns the UPPER_SNAKE_CASE name of a canonical code, e.g.
+// INVALID_ARGUMENT
+func codeName(code errors.Code) string {
+	return strings.ToUpper(strings.Replace(code.String(), " ", "_", -1))
+}
+
+func (e *Error) code() string {
+	if e.Extensions == nil {
+		return ""
+	}
+	s, _ := e.Extensions["code"].(string)
+	return s
+}
+
+func (e *Error) retryAfter() time.Duration {
+	if e.Extensions == nil {
+		return 0
+	}
+	// JSON numbers decode as float64
+	switch n := e.Extensions["retryAfterSeconds"].(type) {
+	case float64:
+		return time.Duration(n) * time.Second
+	case int:
+		return time.Duration(n) * time.Second
+	}
+	return 0
+}
+
+// fieldPath splits a dotted field path into GraphQL path segments
+func fieldPath(field string) []interface{} {
+	if field == "" {
+		return nil
+	}
+	parts := strings.Split(field, ".")
+	path := make([]interface{}, len(parts))
+	for i, p := range parts {
+		path[i] = p
+	}
+	return path
+}
+
+// joinPath flattens a GraphQL path back into a dotted field path, skipping
+// list indices
+func joinPath(path []interface{}) string {
+	var parts []string
+	for _, p := range path {
+		if s, ok := p.(string); ok {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,73 @@
+package graphqlerrors_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/graphqlerrors"
+)
+
+func TestFrom_BadRequest(t *testing.T) {
+	err := errors.Bad(
+		&errors.FieldViolation{Field: "user.email", Description: "Invalid email address"},
+		&errors.FieldViolation{Field: "user.name", Description: "Name is required"},
+	)
+
+	gqlErrs := graphqlerrors.From(err)
+	if len(gqlErrs) != 2 {
+		t.Fatalf("expect one error per violation, but got %d", len(gqlErrs))
+	}
+	if gqlErrs[0].Extensions["code"] != "INVALID_ARGUMENT" {
+		t.Errorf("expect code INVALID_ARGUMENT, but got %v", gqlErrs[0].Extensions["code"])
+	}
+	if len(gqlErrs[0].Path) != 2 || gqlErrs[0].Path[0] != "user" || gqlErrs[0].Path[1] != "email" {
+		t.Errorf("expect the field path, but got %v", gqlErrs[0].Path)
+	}
+}
+
+func TestFrom_RetryHint(t *testing.T) {
+	gqlErrs := graphqlerrors.From(errors.Unavailable(30 * time.Second))
+	if len(gqlErrs) != 1 {
+		t.Fatalf("expect 1 error, but got %d", len(gqlErrs))
+	}
+	if gqlErrs[0].Extensions["retryAfterSeconds"] != 30 {
+		t.Errorf("expect a retry hint, but got %v", gqlErrs[0].Extensions)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "bad request",
+			err: errors.Bad(&errors.FieldViolation{
+				Field:       "user.email",
+				Description: "Invalid email address",
+			}),
+		},
+		{name: "not found", err: errors.NotFound},
+		{name: "permission denied", err: errors.PermissionDenied},
+		{name: "unavailable", err: errors.Unavailable(30 * time.Second)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := graphqlerrors.ToError(graphqlerrors.From(test.err))
+			if !errors.Match(got, test.err) {
+				t.Errorf("expect %v, but got %v", test.err, got)
+			}
+		})
+	}
+}
+
+func TestNil(t *testing.T) {
+	if gqlErrs := graphqlerrors.From(nil); gqlErrs != nil {
+		t.Errorf("expect no errors, but got %v", gqlErrs)
+	}
+	if err := graphqlerrors.ToError(nil); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
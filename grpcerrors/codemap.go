@@ -0,0 +1,73 @@
+package grpcerrors
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/deixis/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CodeMap maps canonical error codes to the gRPC code to emit
+type CodeMap map[errors.Code]codes.Code
+
+var (
+	codeMapMu sync.RWMutex
+	codeMap   = CodeMap{}
+)
+
+// MapCode overrides the gRPC code emitted for errors carrying the given
+// canonical code, e.g. AlreadyExists instead of Aborted for conflicts. It
+// applies to every subsequent Pack and PackWithContext; use PackWith for
+// per-call overrides.
+//
+// MapCode panics when the mapping would leave Unpack ambiguous: the target
+// must be a valid error code (not OK or Unknown) and no other canonical
+// code may already map to it.
+func MapCode(code errors.Code, grpcCode codes.Code) {
+	if grpcCode == codes.OK || grpcCode == codes.Unknown || grpcCode > codes.Unauthenticated {
+		panic(fmt.Sprintf("grpcerrors: cannot map %v to %v", code, grpcCode))
+	}
+	codeMapMu.Lock()
+	defer codeMapMu.Unlock()
+	for c, g := range codeMap {
+		if g == grpcCode && c != code {
+			panic(fmt.Sprintf(
+				"grpcerrors: %v already maps to %v, Unpack would be ambiguous", c, grpcCode,
+			))
+		}
+	}
+	codeMap[code] = grpcCode
+}
+
+// PackWith is like Pack, but rewrites the gRPC code according to the given
+// per-call overrides. Overrides take precedence over the MapCode registry.
+func PackWith(err error, overrides CodeMap) *status.Status {
+	if len(Boundary) > 0 {
+		err = errors.Redact(err, Boundary...)
+	}
+	s, _ := pack(err)
+	return overrideCode(s, err, overrides)
+}
+
+// overrideCode rewrites the code of s according to the per-call overrides
+// and the MapCode registry
+func overrideCode(s *status.Status, err error, overrides CodeMap) *status.Status {
+	if err == nil {
+		return s
+	}
+	code := errors.CodeOf(err)
+	grpcCode, ok := overrides[code]
+	if !ok {
+		codeMapMu.RLock()
+		grpcCode, ok = codeMap[code]
+		codeMapMu.RUnlock()
+	}
+	if !ok || s.Code() == grpcCode {
+		return s
+	}
+	sp := s.Proto()
+	sp.Code = int32(grpcCode)
+	return status.FromProto(sp)
+}
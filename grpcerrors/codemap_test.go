@@ -0,0 +1,75 @@
+package grpcerrors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPackWith(t *testing.T) {
+	err := errors.Aborted(&errors.ConflictViolation{
+		Resource:    "bookings/42",
+		Description: "Booking was modified concurrently",
+	})
+
+	s := grpcerrors.PackWith(err, grpcerrors.CodeMap{
+		errors.CodeAborted: codes.AlreadyExists,
+	})
+	if s.Code() != codes.AlreadyExists {
+		t.Errorf("expect code AlreadyExists, but got %v", s.Code())
+	}
+
+	// Other codes keep the default mapping
+	s = grpcerrors.PackWith(errors.NotFound, grpcerrors.CodeMap{
+		errors.CodeAborted: codes.AlreadyExists,
+	})
+	if s.Code() != codes.NotFound {
+		t.Errorf("expect code NotFound, but got %v", s.Code())
+	}
+}
+
+func TestMapCode(t *testing.T) {
+	grpcerrors.MapCode(errors.CodeAborted, codes.AlreadyExists)
+	defer grpcerrors.MapCode(errors.CodeAborted, codes.Aborted)
+
+	s := grpcerrors.Pack(errors.Aborted())
+	if s.Code() != codes.AlreadyExists {
+		t.Errorf("expect code AlreadyExists, but got %v", s.Code())
+	}
+
+	// Per-call overrides take precedence over the registry
+	s = grpcerrors.PackWith(errors.Aborted(), grpcerrors.CodeMap{
+		errors.CodeAborted: codes.FailedPrecondition,
+	})
+	if s.Code() != codes.FailedPrecondition {
+		t.Errorf("expect code FailedPrecondition, but got %v", s.Code())
+	}
+}
+
+func TestMapCode_Validation(t *testing.T) {
+	expectPanic := func(name string, fn func()) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expect %s to panic, but it did not", name)
+			}
+		}()
+		fn()
+	}
+
+	// OK and Unknown carry no failure type, so Unpack could not recover
+	expectPanic("mapping to OK", func() {
+		grpcerrors.MapCode(errors.CodeAborted, codes.OK)
+	})
+	expectPanic("mapping to Unknown", func() {
+		grpcerrors.MapCode(errors.CodeAborted, codes.Unknown)
+	})
+
+	// Two canonical codes sharing a target would be ambiguous on Unpack
+	grpcerrors.MapCode(errors.CodeAborted, codes.AlreadyExists)
+	defer grpcerrors.MapCode(errors.CodeAborted, codes.Aborted)
+	expectPanic("duplicate target", func() {
+		grpcerrors.MapCode(errors.CodeDataLoss, codes.AlreadyExists)
+	})
+}
@@ -0,0 +1,58 @@
+package grpcerrors
+
+import (
+	"connectrpc.com/connect"
+	"github.com/deixis/errors"
+	anypb "github.com/golang/protobuf/ptypes/any"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+)
+
+// anyTypePrefix is the resolver prefix connect strips from detail type URLs
+const anyTypePrefix = "type.googleapis.com/"
+
+// ToConnect packs err into a connect error carrying the same code and
+// details as Pack, so services migrating from grpc-go to connect-go keep
+// the same typed error surface.
+func ToConnect(err error) *connect.Error {
+	if err == nil {
+		return nil
+	}
+	s := Pack(err)
+	cerr := connect.NewError(connect.Code(s.Code()), errors.New(s.Message()))
+	for _, d := range s.Proto().Details {
+		detail, derr := connect.NewErrorDetail(d)
+		if derr != nil {
+			if OnPackError != nil {
+				OnPackError(derr)
+			}
+			continue
+		}
+		cerr.AddDetail(detail)
+	}
+	return cerr
+}
+
+// FromConnect extracts a connect error into the typed failure it carries,
+// mirroring Unpack. Errors that do not wrap a connect error are returned
+// unchanged.
+func FromConnect(err error) error {
+	if err == nil {
+		return nil
+	}
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) {
+		return err
+	}
+	sp := &spb.Status{
+		Code:    int32(cerr.Code()),
+		Message: cerr.Message(),
+	}
+	for _, d := range cerr.Details() {
+		sp.Details = append(sp.Details, &anypb.Any{
+			TypeUrl: anyTypePrefix + d.Type(),
+			Value:   d.Bytes(),
+		})
+	}
+	return Unpack(status.FromProto(sp).Err())
+}
@@ -0,0 +1,68 @@
+package grpcerrors_test
+
+import (
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+)
+
+func TestConnect_RoundTrip(t *testing.T) {
+	sent := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	})
+
+	cerr := grpcerrors.ToConnect(sent)
+	if cerr.Code() != connect.CodeInvalidArgument {
+		t.Errorf("expect code InvalidArgument, but got %v", cerr.Code())
+	}
+
+	got := grpcerrors.FromConnect(cerr)
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	if f.Violations[0].Field != "email" {
+		t.Errorf("expect the field violation, but got %v", f.Violations)
+	}
+}
+
+func TestConnect_RetryInfo(t *testing.T) {
+	sent := errors.Unavailable(30 * time.Second)
+	got := grpcerrors.FromConnect(grpcerrors.ToConnect(sent))
+	if !errors.IsUnavailable(got) {
+		t.Fatalf("expect an unavailable error, but got %v", got)
+	}
+	if d := errors.RetryAfter(got); d != 30*time.Second {
+		t.Errorf("expect retry delay of 30s, but got %v", d)
+	}
+}
+
+func TestConnect_Foreign(t *testing.T) {
+	// Errors produced by plain connect handlers map to the sentinel errors
+	got := grpcerrors.FromConnect(connect.NewError(
+		connect.CodeNotFound, errors.New("no such booking"),
+	))
+	if !errors.IsNotFound(got) {
+		t.Errorf("expect a not found error, but got %v", got)
+	}
+
+	// Errors that do not wrap a connect error pass through unchanged
+	plain := errors.New("boom")
+	if got := grpcerrors.FromConnect(plain); got != plain {
+		t.Errorf("expect the error to pass through, but got %v", got)
+	}
+}
+
+func TestConnect_Nil(t *testing.T) {
+	if cerr := grpcerrors.ToConnect(nil); cerr != nil {
+		t.Errorf("expect nil error, but got %v", cerr)
+	}
+	if err := grpcerrors.FromConnect(nil); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
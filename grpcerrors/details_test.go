@@ -0,0 +1,223 @@
+package grpcerrors_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDetails_Help(t *testing.T) {
+	sent := errors.WithDetails(errors.FailedPrecondition(), &errors.Help{
+		Links: []*errors.HelpLink{{
+			Description: "Quota documentation",
+			URL:         "https://example.org/docs/quota",
+		}},
+	})
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	for _, d := range errors.Details(got) {
+		if h, ok := d.(*errors.Help); ok {
+			if h.Links[0].URL != "https://example.org/docs/quota" {
+				t.Errorf("expect the help link, but got %v", h.Links)
+			}
+			return
+		}
+	}
+	t.Errorf("expect a Help detail, but got %v", errors.Details(got))
+}
+
+func TestDetails_DebugInfo(t *testing.T) {
+	sent := errors.WithDetails(errors.Aborted(), &errors.DebugInfo{
+		StackEntries: []string{"main.go:42"},
+		Detail:       "replica lag",
+	})
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	for _, d := range errors.Details(got) {
+		if info, ok := d.(*errors.DebugInfo); ok {
+			if info.Detail != "replica lag" {
+				t.Errorf("expect the debug detail, but got %v", info)
+			}
+			return
+		}
+	}
+	t.Errorf("expect a DebugInfo detail, but got %v", errors.Details(got))
+}
+
+func TestUnpack_Typed(t *testing.T) {
+	// Internal, DataLoss, OutOfRange and Unimplemented unpack into their
+	// failure types, so downstream type switches keep working
+	notImpl := grpcerrors.Unpack(status.Error(codes.Unimplemented, "not there yet"))
+	if _, ok := errors.AsNotImplementedFailure(notImpl); !ok {
+		t.Errorf("expect a NotImplementedFailure, but got %v", notImpl)
+	}
+	if !strings.Contains(notImpl.Error(), "not there yet") {
+		t.Errorf("expect the status message to survive, but got %q", notImpl.Error())
+	}
+
+	internal := grpcerrors.Unpack(status.Error(codes.Internal, "shard corrupted"))
+	if _, ok := errors.AsInternalFailure(internal); !ok {
+		t.Errorf("expect an InternalFailure, but got %v", internal)
+	}
+	if !strings.Contains(internal.Error(), "shard corrupted") {
+		t.Errorf("expect the status message to survive, but got %q", internal.Error())
+	}
+
+	dataLoss := grpcerrors.Unpack(status.Error(codes.DataLoss, "bits rotted"))
+	if !errors.IsDataLoss(dataLoss) {
+		t.Errorf("expect a data loss error, but got %v", dataLoss)
+	}
+
+	outOfRange := grpcerrors.Unpack(status.Error(codes.OutOfRange, "page 250"))
+	if !errors.IsOutOfRange(outOfRange) {
+		t.Errorf("expect an out of range error, but got %v", outOfRange)
+	}
+}
+
+func TestDetails_Combined(t *testing.T) {
+	// A failure carrying its violations plus generic details must keep all
+	// of them through a roundtrip
+	sent := errors.WithDetails(
+		errors.ResourceExhausted(
+			&errors.QuotaViolation{
+				Subject:     "project:x/read",
+				Description: "Daily limit exceeded",
+			},
+			errors.WithRetryInfo(time.Minute),
+		),
+		&errors.Correlation{ID: "req-1"},
+	)
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	f, ok := errors.AsQuotaFailure(got)
+	if !ok {
+		t.Fatalf("expect a QuotaFailure, but got %v", got)
+	}
+	if f.Violations[0].Subject != "project:x/read" {
+		t.Errorf("expect the quota violation, but got %v", f.Violations)
+	}
+	if d := errors.RetryAfter(got); d != time.Minute {
+		t.Errorf("expect retry delay of 1m, but got %v", d)
+	}
+	var correlated bool
+	for _, d := range errors.Details(got) {
+		if c, ok := d.(*errors.Correlation); ok && c.ID == "req-1" {
+			correlated = true
+		}
+	}
+	if !correlated {
+		t.Errorf("expect the correlation detail, but got %v", errors.Details(got))
+	}
+}
+
+func TestDetails_RetryInfoFidelity(t *testing.T) {
+	retryAt := time.Now().Add(time.Minute).UTC()
+	sent := errors.WithDetails(errors.Aborted(), &errors.RetryInfo{
+		RetryDelay: 30 * time.Second,
+		RetryAt:    retryAt,
+		Jitter:     0.5,
+	})
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	var info *errors.RetryInfo
+	for _, d := range errors.Details(got) {
+		if i, ok := d.(*errors.RetryInfo); ok {
+			info = i
+		}
+	}
+	if info == nil {
+		t.Fatalf("expect a retry info detail, but got %v", errors.Details(got))
+	}
+	if info.RetryDelay != 30*time.Second {
+		t.Errorf("expect retry delay of 30s, but got %v", info.RetryDelay)
+	}
+	if !info.RetryAt.Equal(retryAt) {
+		t.Errorf("expect retry at %v, but got %v", retryAt, info.RetryAt)
+	}
+	if info.Jitter != 0.5 {
+		t.Errorf("expect jitter of 0.5, but got %v", info.Jitter)
+	}
+}
+
+func TestDetails_AvailabilityRetryInfoFidelity(t *testing.T) {
+	retryAt := time.Now().Add(30 * time.Second).UTC()
+	sent := errors.Unavailable(time.Second)
+	f, _ := errors.AsAvailabilityFailure(sent)
+	f.RetryInfo.RetryAt = retryAt
+	f.RetryInfo.Jitter = 0.25
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	gf, ok := errors.AsAvailabilityFailure(got)
+	if !ok {
+		t.Fatalf("expect an availability failure, but got %v", got)
+	}
+	if gf.RetryInfo.RetryDelay != time.Second {
+		t.Errorf("expect retry delay of 1s, but got %v", gf.RetryInfo.RetryDelay)
+	}
+	if !gf.RetryInfo.RetryAt.Equal(retryAt) {
+		t.Errorf("expect retry at %v, but got %v", retryAt, gf.RetryInfo.RetryAt)
+	}
+	if gf.RetryInfo.Jitter != 0.25 {
+		t.Errorf("expect jitter of 0.25, but got %v", gf.RetryInfo.Jitter)
+	}
+}
+
+func TestDetails_OnPackError(t *testing.T) {
+	var reported error
+	grpcerrors.OnPackError = func(err error) { reported = err }
+	defer func() { grpcerrors.OnPackError = nil }()
+
+	// A detail that cannot be marshalled must be reported rather than
+	// silently dropped
+	sent := errors.WithDetails(errors.Aborted(), (*errdetails.DebugInfo)(nil))
+	s := grpcerrors.Pack(sent)
+	if reported == nil {
+		t.Error("expect the attachment failure to be reported")
+	}
+	if s.Code() != codes.Aborted {
+		t.Errorf("expect code Aborted, but got %v", s.Code())
+	}
+}
+
+func TestGRPCStatus(t *testing.T) {
+	got := grpcerrors.Unpack(status.Error(codes.NotFound, "no such booking"))
+	if !errors.IsNotFound(got) {
+		t.Fatalf("expect a not found error, but got %v", got)
+	}
+
+	// The sentinel mapping drops the message, but the raw status keeps it
+	raw, ok := grpcerrors.GRPCStatus(got)
+	if !ok {
+		t.Fatal("expect the original status to be attached")
+	}
+	if raw.Message() != "no such booking" {
+		t.Errorf("expect the original message, but got %q", raw.Message())
+	}
+
+	if _, ok := grpcerrors.GRPCStatus(errors.New("boom")); ok {
+		t.Error("expect no status for a plain error")
+	}
+}
+
+func TestDetails_StripDebugBoundary(t *testing.T) {
+	grpcerrors.Boundary = []errors.RedactPolicy{errors.StripDebug}
+	defer func() { grpcerrors.Boundary = nil }()
+
+	sent := errors.WithDetails(errors.Aborted(), &errors.DebugInfo{
+		StackEntries: []string{"main.go:42"},
+		Detail:       "replica lag",
+	})
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	for _, d := range errors.Details(got) {
+		if _, ok := d.(*errors.DebugInfo); ok {
+			t.Error("expect debug info to be stripped at the boundary")
+		}
+	}
+}
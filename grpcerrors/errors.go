@@ -2,11 +2,19 @@ package grpcerrors
 
 import (
 	"context"
+	"fmt"
+	"time"
 
-	"github.com/golang/protobuf/ptypes"
 	"github.com/deixis/errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/text/language"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -17,70 +25,150 @@ func Unpack(err error) error {
 		return err
 	}
 
+	// Details decodes every detail message, so do it once and share the
+	// result across the typed failure mapping and the detail extraction
+	decoded := status.Details()
+	uerr := unpack(status, decoded)
+	switch uerr {
+	case errors.NotFound, errors.PermissionDenied, errors.Unauthenticated, errors.DataLoss:
+		// Sentinel errors are shared and must not be mutated
+	default:
+		for _, d := range decoded {
+			ts, ok := d.(*tspb.Timestamp)
+			if !ok {
+				continue
+			}
+			if t, err := ptypes.Timestamp(ts); err == nil {
+				uerr = errors.WithTime(uerr, t)
+			}
+			break
+		}
+	}
+	if details := unpackDetails(status, decoded); len(details) > 0 {
+		uerr = errors.WithDetails(uerr, details...)
+	}
+	if uerr == nil {
+		return nil
+	}
+	return &unpackedError{error: uerr, status: status}
+}
+
+// unpackedError decorates a typed failure with the status it was unpacked
+// from. It unwraps to the failure, so the Is* predicates and As* accessors
+// keep working, and it exposes GRPCStatus, so returning it from a gRPC
+// handler re-serialises the original status untouched.
+type unpackedError struct {
+	error
+	status *status.Status
+}
+
+func (e *unpackedError) Unwrap() error { return e.error }
+
+// GRPCStatus returns the status e was unpacked from
+func (e *unpackedError) GRPCStatus() *status.Status { return e.status }
+
+// GRPCStatus returns the original status err was unpacked from, so callers
+// can reach the untouched code, message and details when the typed mapping
+// is insufficient
+func GRPCStatus(err error) (*status.Status, bool) {
+	for err != nil {
+		if se, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+			return se.GRPCStatus(), true
+		}
+		err = errors.Unwrap(err)
+	}
+	return nil, false
+}
+
+func unpack(status *status.Status, decoded []interface{}) error {
 	switch status.Code() {
 	case codes.OK:
 		return nil
 	case codes.Canceled:
-		return context.Canceled
+		return errors.WithCanceled(context.Canceled)
 	case codes.Unknown:
 		return status.Err()
 	case codes.InvalidArgument:
-		for _, d := range status.Details() {
+		for _, d := range decoded {
 			failure, ok := d.(*errdetails.BadRequest)
 			if !ok {
 				continue
 			}
 
-			violations := make([]*errors.FieldViolation, len(failure.FieldViolations))
+			meta := unpackViolationMeta(decoded)
+			violations := make([]errors.Option, len(failure.FieldViolations))
 			for i, violation := range failure.FieldViolations {
-				violations[i] = &errors.FieldViolation{
+				v := &errors.FieldViolation{
 					Field:       violation.Field,
 					Description: violation.Description,
 				}
+				if m, ok := meta[v.Field]; ok {
+					v.Reason = m.Reason
+					v.Expected = m.Expected
+					v.Actual = m.Actual
+					v.Locales = m.Locales
+				}
+				violations[i] = v
 			}
 			return errors.Bad(violations...)
 		}
 		return errors.Bad()
 	case codes.DeadlineExceeded:
-		return context.DeadlineExceeded
+		return errors.WithDeadlineExceeded(context.DeadlineExceeded)
 	case codes.NotFound:
 		return errors.NotFound
 	case codes.AlreadyExists:
-		// TODO: Other error message?
-		return errors.Aborted()
+		for _, d := range decoded {
+			if info, ok := d.(*errdetails.ResourceInfo); ok {
+				return errors.AlreadyExists(info.ResourceName)
+			}
+		}
+		return errors.AlreadyExists("")
 	case codes.PermissionDenied:
 		return errors.PermissionDenied
 	case codes.ResourceExhausted:
-		for _, d := range status.Details() {
+		for _, d := range decoded {
 			failure, ok := d.(*errdetails.QuotaFailure)
 			if !ok {
 				continue
 			}
 
-			violations := make([]*errors.QuotaViolation, len(failure.Violations))
+			meta := unpackQuotaMeta(decoded)
+			violations := make([]errors.Option, len(failure.Violations))
 			for i, violation := range failure.Violations {
-				violations[i] = &errors.QuotaViolation{
+				v := &errors.QuotaViolation{
 					Subject:     violation.Subject,
 					Description: violation.Description,
 				}
+				if m, ok := meta[v.Subject]; ok {
+					v.Limit = m.Limit
+					v.Current = m.Current
+					v.ResetTime = m.ResetTime
+				}
+				violations[i] = v
 			}
 			return errors.ResourceExhausted(violations...)
 		}
 		return errors.ResourceExhausted()
 	case codes.FailedPrecondition:
-		for _, d := range status.Details() {
+		for _, d := range decoded {
 			failure, ok := d.(*errdetails.PreconditionFailure)
 			if !ok {
 				continue
 			}
 
-			violations := make([]*errors.PreconditionViolation, len(failure.Violations))
+			meta := unpackPreconditionMeta(decoded)
+			violations := make([]errors.Option, len(failure.Violations))
 			for i, violation := range failure.Violations {
-				violations[i] = &errors.PreconditionViolation{
+				v := &errors.PreconditionViolation{
 					Type:        violation.Type,
 					Subject:     violation.Subject,
 					Description: violation.Description,
 				}
+				if m, ok := meta[v.Type+"/"+v.Subject]; ok {
+					v.Locales = m.Locales
+				}
+				violations[i] = v
 			}
 			return errors.FailedPrecondition(violations...)
 		}
@@ -88,23 +176,70 @@ func Unpack(err error) error {
 	case codes.Aborted:
 		return errors.Aborted()
 	case codes.OutOfRange:
-		return status.Err()
+		for _, d := range decoded {
+			failure, ok := d.(*errdetails.BadRequest)
+			if !ok {
+				continue
+			}
+
+			violations := make([]errors.Option, len(failure.FieldViolations))
+			for i, violation := range failure.FieldViolations {
+				v := &errors.RangeViolation{Field: violation.Field}
+				fmt.Sscanf(violation.Description,
+					"value %d out of range [%d, %d]", &v.Actual, &v.Min, &v.Max,
+				)
+				violations[i] = v
+			}
+			return errors.OutOfRange(violations...)
+		}
+		return errors.OutOfRange()
 	case codes.Unimplemented:
-		return status.Err()
+		return errors.WithNotImplemented(errors.New(status.Message()))
 	case codes.Internal:
-		return status.Err()
+		uerr := errors.WithInternal(errors.New(status.Message()))
+		for _, d := range decoded {
+			info, ok := d.(*errdetails.DebugInfo)
+			if !ok {
+				continue
+			}
+			if f, ok := errors.AsInternalFailure(uerr); ok {
+				f.DebugInfo = &errors.DebugInfo{
+					StackEntries: info.StackEntries,
+					Detail:       info.Detail,
+				}
+			}
+			break
+		}
+		return uerr
 	case codes.Unavailable:
-		for _, d := range status.Details() {
+		reason := unpackAvailabilityReason(decoded)
+		for _, d := range decoded {
 			info, ok := d.(*errdetails.RetryInfo)
 			if !ok {
 				continue
 			}
-			d, _ := ptypes.Duration(info.RetryDelay)
-			return errors.Unavailable(d)
+			delay, _ := ptypes.Duration(info.RetryDelay)
+			err := errors.UnavailableBecause(reason, delay)
+			if f, ok := errors.AsAvailabilityFailure(err); ok {
+				unpackRetryMeta(decoded, &f.RetryInfo)
+			}
+			return err
 		}
-		return errors.Unavailable(0)
+		return errors.UnavailableBecause(reason, 0)
 	case codes.DataLoss:
-		return status.Err()
+		for _, d := range decoded {
+			info, ok := d.(*errdetails.DebugInfo)
+			if !ok {
+				continue
+			}
+			return &errors.DataLossFailure{
+				DebugInfo: &errors.DebugInfo{
+					StackEntries: info.StackEntries,
+					Detail:       info.Detail,
+				},
+			}
+		}
+		return errors.DataLoss
 	case codes.Unauthenticated:
 		return errors.Unauthenticated
 	default:
@@ -112,44 +247,202 @@ func Unpack(err error) error {
 	}
 }
 
+// Boundary lists the redaction policies applied to every error packed by
+// this transport. Services facing external clients typically set it to
+// errors.StripInternal and errors.StripDebug at start-up.
+var Boundary []errors.RedactPolicy
+
 // Pack returns a Status representing err if it was produced from an
 // `*errors.Error` struct.
 func Pack(err error) *status.Status {
+	if len(Boundary) > 0 {
+		err = errors.Redact(err, Boundary...)
+	} else if s, ok := cachedStatus(err); ok {
+		return overrideCode(s, err, nil)
+	}
+	s, _ := pack(err)
+	return overrideCode(s, err, nil)
+}
+
+// PackWithContext is like Pack, but resolves the localised message matching
+// the locales carried by ctx (see errors.WithLocale) or the accept-language
+// request metadata, keeping only the matched translation in the status
+// details.
+func PackWithContext(ctx context.Context, err error) *status.Status {
+	if len(Boundary) > 0 {
+		err = errors.Redact(err, Boundary...)
+	}
 	s, _ := pack(err)
-	return s
+	s = overrideCode(s, err, nil)
+	preferred := acceptedLocales(ctx)
+	if len(preferred) == 0 {
+		return s
+	}
+	msg := errors.LocalizedMessage(err, preferred...)
+	if msg == "" {
+		return s
+	}
+
+	unpacked := s.Details()
+	sp := s.Proto()
+	details := sp.Details[:0]
+	for i, d := range sp.Details {
+		if m, ok := unpacked[i].(*errdetails.LocalizedMessage); ok && m.Message != msg {
+			continue
+		}
+		details = append(details, d)
+	}
+	sp.Details = details
+	return status.FromProto(sp)
+}
+
+// acceptedLocales returns the preferred locales of the request, either set
+// explicitly on the context or carried by the accept-language metadata
+func acceptedLocales(ctx context.Context) []language.Tag {
+	if tags := errors.LocaleFromContext(ctx); len(tags) > 0 {
+		return tags
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	for _, v := range md.Get("accept-language") {
+		if tags, _, err := language.ParseAcceptLanguage(v); err == nil && len(tags) > 0 {
+			return tags
+		}
+	}
+	return nil
 }
 
+// OnPackError is invoked when status details cannot be attached, e.g. a
+// detail failed to marshal. By default attachment failures drop the details
+// silently and the status keeps its code and message.
+var OnPackError func(error)
+
 // Pack returns a Status representing err if it was produced from an
 // `*errors.Error` struct. Otherwise, ok is false and a Status is returned
 // with codes.Unknown and the original error message.
 func pack(err error) (*status.Status, bool) {
+	s, details, ok := packStatus(err)
+	details = append(details, packDetails(errors.Details(err))...)
+	if t := errors.Time(err); !t.IsZero() {
+		if ts, err := ptypes.TimestampProto(t); err == nil {
+			details = append(details, ts)
+		}
+	}
+	if len(details) > 0 {
+		s = withDetails(s, details...)
+	}
+	var raws []*any.Any
+	for _, d := range errors.Details(err) {
+		if a, ok := d.(*any.Any); ok {
+			raws = append(raws, a)
+		}
+	}
+	if len(raws) > 0 {
+		sp := s.Proto()
+		sp.Details = append(sp.Details, raws...)
+		s = status.FromProto(sp)
+	}
+	if msg := errors.UserMessage(err); msg != "" {
+		// Clients see the user message; logs keep the full internal chain
+		sp := s.Proto()
+		sp.Message = msg
+		s = status.FromProto(sp)
+	}
+	return s, ok
+}
+
+// withDetails attaches details to s in a single call. When attachment
+// fails, the failure is reported to OnPackError and s is returned without
+// the details.
+func withDetails(s *status.Status, details ...proto.Message) *status.Status {
+	ds, err := s.WithDetails(details...)
+	if err != nil {
+		if OnPackError != nil {
+			OnPackError(err)
+		}
+		return s
+	}
+	return ds
+}
+
+// packStatus maps err to a status and the detail messages carrying its
+// violations. The details are returned rather than attached, so pack can
+// combine them with the generic details in a single WithDetails call.
+func packStatus(err error) (*status.Status, []proto.Message, bool) {
 	if err == nil {
-		return status.New(codes.OK, ""), true
+		return status.New(codes.OK, ""), nil, true
 	}
 
 	switch err {
 	case context.Canceled:
-		return status.New(codes.Canceled, err.Error()), true
+		return status.New(codes.Canceled, err.Error()), nil, true
 	case context.DeadlineExceeded:
-		return status.New(codes.DeadlineExceeded, err.Error()), true
+		return status.New(codes.DeadlineExceeded, err.Error()), nil, true
 	}
 
 	switch err := err.(type) {
+	case *errors.MultiFailure:
+		if len(err.Errors) == 0 {
+			return status.New(codes.Unknown, err.Error()), nil, false
+		}
+		// The status carries the code of the first member, but the details
+		// of every member
+		s, ok := pack(err.Errors[0])
+		sp := s.Proto()
+		sp.Message = err.Error()
+		for _, member := range err.Errors[1:] {
+			ms, _ := pack(member)
+			sp.Details = append(sp.Details, ms.Proto().Details...)
+		}
+		return status.FromProto(sp), nil, ok
+	case *errors.TimeoutFailure:
+		return status.New(codes.DeadlineExceeded, err.Error()), nil, true
+	case *errors.CancelledFailure:
+		return status.New(codes.Canceled, err.Error()), nil, true
 	case *errors.AvailabilityFailure:
 		s := status.New(codes.Unavailable, err.Error())
-		detail := &errdetails.RetryInfo{
+		details := []proto.Message{&errdetails.RetryInfo{
 			RetryDelay: ptypes.DurationProto(err.RetryInfo.RetryDelay),
+		}}
+		if meta := packRetryMeta(&err.RetryInfo); meta != nil {
+			details = append(details, meta)
+		}
+		if err.Reason != errors.ReasonUnspecified {
+			details = append(details, &structpb.Struct{
+				Fields: map[string]*structpb.Value{
+					availabilityReasonKey: stringValue(err.Reason.String()),
+				},
+			})
 		}
-		if s, err := s.WithDetails(detail); err == nil {
-			return s, true
+		return s, details, true
+	case *errors.DataLossFailure:
+		s := status.New(codes.DataLoss, err.Error())
+		if err.DebugInfo == nil {
+			return s, nil, true
 		}
-		return s, true
+		return s, []proto.Message{&errdetails.DebugInfo{
+			StackEntries: err.DebugInfo.StackEntries,
+			Detail:       err.DebugInfo.Detail,
+		}}, true
+	case *errors.InternalFailure:
+		s := status.New(codes.Internal, err.Error())
+		if err.DebugInfo == nil {
+			return s, nil, true
+		}
+		return s, []proto.Message{&errdetails.DebugInfo{
+			StackEntries: err.DebugInfo.StackEntries,
+			Detail:       err.DebugInfo.Detail,
+		}}, true
+	case *errors.NotImplementedFailure:
+		return status.New(codes.Unimplemented, err.Error()), nil, true
 	case *errors.PermissionFailure:
-		return status.New(codes.PermissionDenied, err.Error()), true
+		return status.New(codes.PermissionDenied, err.Error()), nil, true
 	case *errors.AuthenticationFailure:
-		return status.New(codes.Unauthenticated, err.Error()), true
+		return status.New(codes.Unauthenticated, err.Error()), nil, true
 	case *errors.MissingFailure:
-		return status.New(codes.NotFound, err.Error()), true
+		return status.New(codes.NotFound, err.Error()), nil, true
 	case *errors.BadRequest:
 		s := status.New(codes.InvalidArgument, err.Error())
 		detail := &errdetails.BadRequest{
@@ -161,10 +454,23 @@ func pack(err error) (*status.Status, bool) {
 				Description: violation.Description,
 			}
 		}
-		if s, err := s.WithDetails(detail); err == nil {
-			return s, true
+		details := []proto.Message{detail}
+		if meta := packViolationMeta(err.Violations); meta != nil {
+			details = append(details, meta)
+		}
+		return s, details, true
+	case *errors.OutOfRangeFailure:
+		s := status.New(codes.OutOfRange, err.Error())
+		detail := &errdetails.BadRequest{
+			FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(err.Violations)),
+		}
+		for i, violation := range err.Violations {
+			detail.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description(),
+			}
 		}
-		return s, true
+		return s, []proto.Message{detail}, true
 	case *errors.PreconditionFailure:
 		s := status.New(codes.FailedPrecondition, err.Error())
 		detail := &errdetails.PreconditionFailure{
@@ -177,12 +483,21 @@ func pack(err error) (*status.Status, bool) {
 				Description: violation.Description,
 			}
 		}
-		if s, err := s.WithDetails(detail); err == nil {
-			return s, true
+		details := []proto.Message{detail}
+		if meta := packPreconditionMeta(err.Violations); meta != nil {
+			details = append(details, meta)
 		}
-		return s, true
+		return s, details, true
 	case *errors.ConflictFailure:
-		return status.New(codes.Aborted, err.Error()), true
+		return status.New(codes.Aborted, err.Error()), nil, true
+	case *errors.ExistsFailure:
+		s := status.New(codes.AlreadyExists, err.Error())
+		if err.Resource == "" {
+			return s, nil, true
+		}
+		return s, []proto.Message{
+			&errdetails.ResourceInfo{ResourceName: err.Resource},
+		}, true
 	case *errors.QuotaFailure:
 		s := status.New(codes.ResourceExhausted, err.Error())
 		detail := &errdetails.QuotaFailure{
@@ -194,11 +509,538 @@ func pack(err error) (*status.Status, bool) {
 				Description: violation.Description,
 			}
 		}
-		if s, err := s.WithDetails(detail); err == nil {
-			return s, true
+		details := []proto.Message{detail}
+		if meta := packQuotaMeta(err.Violations); meta != nil {
+			details = append(details, meta)
 		}
-		return s, true
+		return s, details, true
 	default:
-		return status.New(codes.Unknown, err.Error()), false
+		if cause := errors.Unwrap(err); cause != nil {
+			return packStatus(cause)
+		}
+		return status.New(codes.Unknown, err.Error()), nil, false
+	}
+}
+
+// violationMetaKey discriminates the struct detail that carries the
+// structured metadata (reason, expected, actual) of field violations
+const violationMetaKey = "fieldViolationMeta"
+
+// packViolationMeta returns a struct detail carrying the structured
+// metadata of the given violations, or nil when none of them has any
+func packViolationMeta(violations []*errors.FieldViolation) *structpb.Struct {
+	fields := map[string]*structpb.Value{}
+	for _, v := range violations {
+		if v.Reason == "" && v.Expected == "" && v.Actual == "" && len(v.Locales) == 0 {
+			continue
+		}
+		meta := map[string]*structpb.Value{}
+		if v.Reason != "" {
+			meta["reason"] = stringValue(v.Reason)
+		}
+		if v.Expected != "" {
+			meta["expected"] = stringValue(v.Expected)
+		}
+		if v.Actual != "" {
+			meta["actual"] = stringValue(v.Actual)
+		}
+		if len(v.Locales) > 0 {
+			meta["locales"] = localesValue(v.Locales)
+		}
+		fields[v.Field] = &structpb.Value{
+			Kind: &structpb.Value_StructValue{
+				StructValue: &structpb.Struct{Fields: meta},
+			},
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			violationMetaKey: {
+				Kind: &structpb.Value_StructValue{
+					StructValue: &structpb.Struct{Fields: fields},
+				},
+			},
+		},
+	}
+}
+
+// unpackViolationMeta extracts the per-field structured metadata attached
+// by packViolationMeta, keyed by field path
+func unpackViolationMeta(decoded []interface{}) map[string]*errors.FieldViolation {
+	meta := map[string]*errors.FieldViolation{}
+	for _, d := range decoded {
+		st, ok := d.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		wrapper, ok := st.Fields[violationMetaKey]
+		if !ok {
+			continue
+		}
+		fields, ok := wrapper.Kind.(*structpb.Value_StructValue)
+		if !ok {
+			continue
+		}
+		for field, v := range fields.StructValue.Fields {
+			sv, ok := v.Kind.(*structpb.Value_StructValue)
+			if !ok {
+				continue
+			}
+			m := &errors.FieldViolation{Field: field}
+			if r, ok := sv.StructValue.Fields["reason"]; ok {
+				m.Reason = stringOf(r)
+			}
+			if r, ok := sv.StructValue.Fields["expected"]; ok {
+				m.Expected = stringOf(r)
+			}
+			if r, ok := sv.StructValue.Fields["actual"]; ok {
+				m.Actual = stringOf(r)
+			}
+			if r, ok := sv.StructValue.Fields["locales"]; ok {
+				m.Locales = localesOf(r)
+			}
+			meta[field] = m
+		}
+	}
+	return meta
+}
+
+// preconditionMetaKey discriminates the struct detail that carries the
+// structured metadata (localised descriptions) of precondition violations
+const preconditionMetaKey = "preconditionViolationMeta"
+
+// packPreconditionMeta returns a struct detail carrying the structured
+// metadata of the given violations, keyed by type and subject, or nil when
+// none of them has any
+func packPreconditionMeta(violations []*errors.PreconditionViolation) *structpb.Struct {
+	fields := map[string]*structpb.Value{}
+	for _, v := range violations {
+		if len(v.Locales) == 0 {
+			continue
+		}
+		meta := map[string]*structpb.Value{
+			"locales": localesValue(v.Locales),
+		}
+		fields[v.Type+"/"+v.Subject] = &structpb.Value{
+			Kind: &structpb.Value_StructValue{
+				StructValue: &structpb.Struct{Fields: meta},
+			},
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			preconditionMetaKey: {
+				Kind: &structpb.Value_StructValue{
+					StructValue: &structpb.Struct{Fields: fields},
+				},
+			},
+		},
+	}
+}
+
+// unpackPreconditionMeta extracts the per-violation structured metadata
+// attached by packPreconditionMeta, keyed by type and subject
+func unpackPreconditionMeta(decoded []interface{}) map[string]*errors.PreconditionViolation {
+	meta := map[string]*errors.PreconditionViolation{}
+	for _, d := range decoded {
+		st, ok := d.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		wrapper, ok := st.Fields[preconditionMetaKey]
+		if !ok {
+			continue
+		}
+		fields, ok := wrapper.Kind.(*structpb.Value_StructValue)
+		if !ok {
+			continue
+		}
+		for key, v := range fields.StructValue.Fields {
+			sv, ok := v.Kind.(*structpb.Value_StructValue)
+			if !ok {
+				continue
+			}
+			m := &errors.PreconditionViolation{}
+			if r, ok := sv.StructValue.Fields["locales"]; ok {
+				m.Locales = localesOf(r)
+			}
+			meta[key] = m
+		}
+	}
+	return meta
+}
+
+// localesValue converts per-locale description variants into a struct value
+func localesValue(locales errors.LocalisedString) *structpb.Value {
+	fields := make(map[string]*structpb.Value, len(locales))
+	for locale, msg := range locales {
+		fields[locale] = stringValue(msg)
+	}
+	return &structpb.Value{
+		Kind: &structpb.Value_StructValue{
+			StructValue: &structpb.Struct{Fields: fields},
+		},
+	}
+}
+
+// localesOf extracts per-locale description variants from a struct value
+func localesOf(v *structpb.Value) errors.LocalisedString {
+	sv, ok := v.Kind.(*structpb.Value_StructValue)
+	if !ok {
+		return nil
+	}
+	locales := make(errors.LocalisedString, len(sv.StructValue.Fields))
+	for locale, msg := range sv.StructValue.Fields {
+		locales[locale] = stringOf(msg)
+	}
+	return locales
+}
+
+// availabilityReasonKey discriminates the struct detail that carries the
+// reason of an AvailabilityFailure
+const availabilityReasonKey = "availabilityReason"
+
+// unpackAvailabilityReason extracts the availability reason attached to the
+// status, if any
+func unpackAvailabilityReason(decoded []interface{}) errors.AvailabilityReason {
+	for _, d := range decoded {
+		st, ok := d.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		if v, ok := st.Fields[availabilityReasonKey]; ok {
+			return errors.ParseAvailabilityReason(stringOf(v))
+		}
+	}
+	return errors.ReasonUnspecified
+}
+
+// retryInfoMetaKey discriminates the struct detail that carries the retry
+// fields errdetails.RetryInfo cannot express (RetryAt, Jitter)
+const retryInfoMetaKey = "retryInfoMeta"
+
+// packRetryMeta returns a struct detail carrying the retry fields the
+// google.rpc detail drops, or nil when the info only has a delay
+func packRetryMeta(info *errors.RetryInfo) *structpb.Struct {
+	if info.RetryAt.IsZero() && info.Jitter == 0 {
+		return nil
+	}
+	meta := map[string]*structpb.Value{}
+	if !info.RetryAt.IsZero() {
+		meta["retryAt"] = stringValue(info.RetryAt.Format(time.RFC3339Nano))
+	}
+	if info.Jitter > 0 {
+		meta["jitter"] = numberValue(info.Jitter)
+	}
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			retryInfoMetaKey: {
+				Kind: &structpb.Value_StructValue{
+					StructValue: &structpb.Struct{Fields: meta},
+				},
+			},
+		},
+	}
+}
+
+// unpackRetryMeta restores the fields attached by packRetryMeta into info
+func unpackRetryMeta(decoded []interface{}, info *errors.RetryInfo) {
+	for _, d := range decoded {
+		st, ok := d.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		wrapper, ok := st.Fields[retryInfoMetaKey]
+		if !ok {
+			continue
+		}
+		sv, ok := wrapper.Kind.(*structpb.Value_StructValue)
+		if !ok {
+			continue
+		}
+		if v, ok := sv.StructValue.Fields["retryAt"]; ok {
+			if t, err := time.Parse(time.RFC3339Nano, stringOf(v)); err == nil {
+				info.RetryAt = t
+			}
+		}
+		if v, ok := sv.StructValue.Fields["jitter"]; ok {
+			info.Jitter = numberOf(v)
+		}
+		return
+	}
+}
+
+// quotaMetaKey discriminates the struct detail that carries the structured
+// metadata (limit, current, reset time) of quota violations
+const quotaMetaKey = "quotaViolationMeta"
+
+// packQuotaMeta returns a struct detail carrying the structured metadata of
+// the given violations, or nil when none of them has any
+func packQuotaMeta(violations []*errors.QuotaViolation) *structpb.Struct {
+	fields := map[string]*structpb.Value{}
+	for _, v := range violations {
+		if v.Limit == 0 && v.Current == 0 && v.ResetTime.IsZero() {
+			continue
+		}
+		meta := map[string]*structpb.Value{}
+		if v.Limit != 0 {
+			meta["limit"] = numberValue(float64(v.Limit))
+		}
+		if v.Current != 0 {
+			meta["current"] = numberValue(float64(v.Current))
+		}
+		if !v.ResetTime.IsZero() {
+			meta["resetTime"] = stringValue(v.ResetTime.Format(time.RFC3339Nano))
+		}
+		fields[v.Subject] = &structpb.Value{
+			Kind: &structpb.Value_StructValue{
+				StructValue: &structpb.Struct{Fields: meta},
+			},
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			quotaMetaKey: {
+				Kind: &structpb.Value_StructValue{
+					StructValue: &structpb.Struct{Fields: fields},
+				},
+			},
+		},
+	}
+}
+
+// unpackQuotaMeta extracts the per-subject structured metadata attached by
+// packQuotaMeta, keyed by subject
+func unpackQuotaMeta(decoded []interface{}) map[string]*errors.QuotaViolation {
+	meta := map[string]*errors.QuotaViolation{}
+	for _, d := range decoded {
+		st, ok := d.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		wrapper, ok := st.Fields[quotaMetaKey]
+		if !ok {
+			continue
+		}
+		fields, ok := wrapper.Kind.(*structpb.Value_StructValue)
+		if !ok {
+			continue
+		}
+		for subject, v := range fields.StructValue.Fields {
+			sv, ok := v.Kind.(*structpb.Value_StructValue)
+			if !ok {
+				continue
+			}
+			m := &errors.QuotaViolation{Subject: subject}
+			if n, ok := sv.StructValue.Fields["limit"]; ok {
+				m.Limit = int64(numberOf(n))
+			}
+			if n, ok := sv.StructValue.Fields["current"]; ok {
+				m.Current = int64(numberOf(n))
+			}
+			if r, ok := sv.StructValue.Fields["resetTime"]; ok {
+				if t, err := time.Parse(time.RFC3339Nano, stringOf(r)); err == nil {
+					m.ResetTime = t
+				}
+			}
+			meta[subject] = m
+		}
+	}
+	return meta
+}
+
+func numberValue(f float64) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: f}}
+}
+
+func numberOf(v *structpb.Value) float64 {
+	if nv, ok := v.Kind.(*structpb.Value_NumberValue); ok {
+		return nv.NumberValue
+	}
+	return 0
+}
+
+func stringValue(s string) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}
+}
+
+func stringOf(v *structpb.Value) string {
+	if sv, ok := v.Kind.(*structpb.Value_StringValue); ok {
+		return sv.StringValue
+	}
+	return ""
+}
+
+// packDetails converts the details attached with `errors.WithDetails` into
+// their protobuf representation. Details without a protobuf representation
+// are dropped.
+func packDetails(details []interface{}) []proto.Message {
+	var messages []proto.Message
+	for _, d := range details {
+		switch d := d.(type) {
+		case *errors.RetryInfo:
+			messages = append(messages, &errdetails.RetryInfo{
+				RetryDelay: ptypes.DurationProto(d.RetryDelay),
+			})
+			if meta := packRetryMeta(d); meta != nil {
+				messages = append(messages, meta)
+			}
+		case *errors.Correlation:
+			messages = append(messages, &errdetails.RequestInfo{
+				RequestId: d.ID,
+			})
+		case errors.Metadata:
+			fields := make(map[string]*structpb.Value, len(d))
+			for k, v := range d {
+				fields[k] = &structpb.Value{
+					Kind: &structpb.Value_StringValue{StringValue: v},
+				}
+			}
+			messages = append(messages, &structpb.Struct{Fields: fields})
+		case *errors.DebugInfo:
+			messages = append(messages, &errdetails.DebugInfo{
+				StackEntries: d.StackEntries,
+				Detail:       d.Detail,
+			})
+		case *errors.LocalisedMessage:
+			messages = append(messages, &errdetails.LocalizedMessage{
+				Locale:  d.Locale.String(),
+				Message: d.Message,
+			})
+		case *errors.Help:
+			links := make([]*errdetails.Help_Link, len(d.Links))
+			for i, l := range d.Links {
+				links[i] = &errdetails.Help_Link{
+					Description: l.Description,
+					Url:         l.URL,
+				}
+			}
+			messages = append(messages, &errdetails.Help{Links: links})
+		case *any.Any:
+			// Re-attached verbatim in pack; wrapping it again would bury the
+			// original type URL
+		case proto.Message:
+			messages = append(messages, d)
+		}
+	}
+	return messages
+}
+
+// unpackDetails extracts the status details that are not already consumed
+// by the typed failure mapping in `unpack`
+func unpackDetails(s *status.Status, decoded []interface{}) []interface{} {
+	if s.Code() == codes.OK || len(decoded) == 0 {
+		return nil
+	}
+
+	var details []interface{}
+	// Proto clones the whole status, so only pay for it when an undecodable
+	// detail needs its raw Any preserved
+	var raw []*any.Any
+	for i, d := range decoded {
+		switch d := d.(type) {
+		case *errdetails.BadRequest, *errdetails.QuotaFailure, *errdetails.PreconditionFailure:
+			// Consumed by the typed failure mapping
+		case *errdetails.RequestInfo:
+			details = append(details, &errors.Correlation{ID: d.RequestId})
+		case *errdetails.ResourceInfo:
+			if s.Code() == codes.AlreadyExists {
+				// Consumed by the typed failure mapping
+				continue
+			}
+			details = append(details, d)
+		case *tspb.Timestamp:
+			// Consumed as the failure creation time in Unpack
+		case *structpb.Struct:
+			if _, ok := d.Fields[violationMetaKey]; ok {
+				// Consumed by the field violation mapping
+				continue
+			}
+			if _, ok := d.Fields[quotaMetaKey]; ok {
+				// Consumed by the quota violation mapping
+				continue
+			}
+			if _, ok := d.Fields[preconditionMetaKey]; ok {
+				// Consumed by the precondition violation mapping
+				continue
+			}
+			if _, ok := d.Fields[availabilityReasonKey]; ok {
+				// Consumed by the availability failure mapping
+				continue
+			}
+			if _, ok := d.Fields[retryInfoMetaKey]; ok {
+				// Consumed by the retry info mapping
+				continue
+			}
+			meta := make(errors.Metadata, len(d.Fields))
+			for k, v := range d.Fields {
+				if sv, ok := v.Kind.(*structpb.Value_StringValue); ok {
+					meta[k] = sv.StringValue
+				}
+			}
+			details = append(details, meta)
+		case *errdetails.DebugInfo:
+			if s.Code() == codes.DataLoss || s.Code() == codes.Internal {
+				// Consumed by the typed failure mapping
+				continue
+			}
+			details = append(details, &errors.DebugInfo{
+				StackEntries: d.StackEntries,
+				Detail:       d.Detail,
+			})
+		case *errdetails.RetryInfo:
+			if s.Code() == codes.Unavailable {
+				// Consumed by `errors.Unavailable`
+				continue
+			}
+			delay, err := ptypes.Duration(d.RetryDelay)
+			if err != nil {
+				continue
+			}
+			info := &errors.RetryInfo{RetryDelay: delay}
+			unpackRetryMeta(decoded, info)
+			details = append(details, info)
+		case *errdetails.LocalizedMessage:
+			tag, err := language.Parse(d.Locale)
+			if err != nil {
+				continue
+			}
+			details = append(details, &errors.LocalisedMessage{
+				Locale:  tag,
+				Message: d.Message,
+			})
+		case *errdetails.Help:
+			links := make([]*errors.HelpLink, len(d.Links))
+			for i, l := range d.Links {
+				links[i] = &errors.HelpLink{
+					Description: l.Description,
+					URL:         l.Url,
+				}
+			}
+			details = append(details, &errors.Help{Links: links})
+		default:
+			if _, isErr := d.(error); isErr {
+				if raw == nil {
+					raw = s.Proto().Details
+				}
+				if i < len(raw) {
+					// The detail type isn't linked into this binary; carry the
+					// raw Any so re-packing stays transparent for proxies
+					details = append(details, raw[i])
+					continue
+				}
+			}
+			details = append(details, d)
+		}
 	}
+	return details
 }
@@ -0,0 +1,98 @@
+package grpcerrors
+
+import (
+	"context"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/spine/log"
+	"github.com/deixis/spine/stats"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor converts the errors returned by unary handlers
+// into rich statuses with details (see PackWithContext), so clients can
+// unpack them into typed failures.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return res, PackWithContext(ctx, err).Err()
+		}
+		return res, nil
+	}
+}
+
+// StreamServerInterceptor mirrors UnaryServerInterceptor for stream
+// handlers. Errors raised mid-stream terminate the stream with a rich
+// status instead of a bare message.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := handler(srv, ss); err != nil {
+			return PackWithContext(ss.Context(), err).Err()
+		}
+		return nil
+	}
+}
+
+// UnaryRecoveryInterceptor catches panics from unary handlers, converts
+// them via errors.Recover into an InternalFailure with DebugInfo, and
+// returns it as an Internal status instead of killing the serving
+// goroutine. Each recovered panic is logged with its stack and counted on
+// the `grpc.panic` stat.
+func UnaryRecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (res interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoverPanic(ctx, info.FullMethod, recovered)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor mirrors UnaryRecoveryInterceptor for stream
+// handlers.
+func StreamRecoveryInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoverPanic(ss.Context(), info.FullMethod, recovered)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recoverPanic converts a recovered panic into an Internal status, logging
+// it with the failing method
+func recoverPanic(ctx context.Context, method string, recovered interface{}) error {
+	err := errors.Recover(recovered)
+
+	log.Err(ctx, "grpc.panic", "Recovered from panic",
+		log.String("method", method),
+		log.Error(err),
+	)
+	stats.Inc(ctx, "grpc.panic")
+
+	return PackWithContext(ctx, err).Err()
+}
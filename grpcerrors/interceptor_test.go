@@ -0,0 +1,104 @@
+package grpcerrors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"google.golang.org/grpc"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	sent := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, sent
+	}
+
+	_, err := grpcerrors.UnaryServerInterceptor()(
+		context.Background(), nil, &grpc.UnaryServerInfo{}, handler,
+	)
+	got := grpcerrors.Unpack(err)
+	if !errors.Match(got, sent) {
+		t.Errorf("expect %v, but got %v", sent, got)
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	sent := errors.ResourceExhausted(&errors.QuotaViolation{
+		Subject:     "project:x/read",
+		Description: "Daily limit exceeded",
+	})
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		return sent
+	}
+
+	err := grpcerrors.StreamServerInterceptor()(
+		nil, &fakeStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, handler,
+	)
+	got := grpcerrors.Unpack(err)
+	if !errors.Match(got, sent) {
+		t.Errorf("expect %v, but got %v", sent, got)
+	}
+
+	handler = func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+	err = grpcerrors.StreamServerInterceptor()(
+		nil, &fakeStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, handler,
+	)
+	if err != nil {
+		t.Errorf("expect no error, but got %v", err)
+	}
+}
+
+func TestUnaryRecoveryInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+
+	_, err := grpcerrors.UnaryRecoveryInterceptor()(
+		context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Get"}, handler,
+	)
+	got := grpcerrors.Unpack(err)
+	if _, ok := errors.AsInternalFailure(got); !ok {
+		t.Errorf("expect an internal failure, but got %v", got)
+	}
+
+	handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	res, err := grpcerrors.UnaryRecoveryInterceptor()(
+		context.Background(), nil, &grpc.UnaryServerInfo{}, handler,
+	)
+	if err != nil {
+		t.Errorf("expect no error, but got %v", err)
+	}
+	if res != "ok" {
+		t.Errorf("expect the handler response, but got %v", res)
+	}
+}
+
+func TestStreamRecoveryInterceptor(t *testing.T) {
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		panic("boom")
+	}
+
+	err := grpcerrors.StreamRecoveryInterceptor()(
+		nil, &fakeStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/svc/Watch"}, handler,
+	)
+	got := grpcerrors.Unpack(err)
+	if _, ok := errors.AsInternalFailure(got); !ok {
+		t.Errorf("expect an internal failure, but got %v", got)
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
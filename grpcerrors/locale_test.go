@@ -0,0 +1,103 @@
+package grpcerrors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLocale_RoundTrip(t *testing.T) {
+	sent := errors.WithLocalizedMessage(
+		errors.WithLocalizedMessage(
+			errors.Bad(&errors.FieldViolation{
+				Field:       "email",
+				Description: "Invalid email address",
+			}),
+			language.French, "Adresse e-mail invalide",
+		),
+		language.German, "Ungültige E-Mail-Adresse",
+	)
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	if msg := errors.LocalizedMessage(got, language.German); msg != "Ungültige E-Mail-Adresse" {
+		t.Errorf("expect the German message, but got %q", msg)
+	}
+	if msg := errors.LocalizedMessage(got, language.French); msg != "Adresse e-mail invalide" {
+		t.Errorf("expect the French message, but got %q", msg)
+	}
+}
+
+func TestLocale_ViolationRoundTrip(t *testing.T) {
+	sent := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+		Locales: errors.LocalisedString{
+			"fr": "Adresse e-mail invalide",
+			"de": "Ungültige E-Mail-Adresse",
+		},
+	})
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	v := f.Violations[0]
+	if msg := v.LocalizedDescription(language.French); msg != "Adresse e-mail invalide" {
+		t.Errorf("expect the French description, but got %q", msg)
+	}
+	if msg := v.LocalizedDescription(language.German); msg != "Ungültige E-Mail-Adresse" {
+		t.Errorf("expect the German description, but got %q", msg)
+	}
+}
+
+func TestLocale_PreconditionRoundTrip(t *testing.T) {
+	sent := errors.FailedPrecondition(&errors.PreconditionViolation{
+		Type:        "TOS",
+		Subject:     "auth.service",
+		Description: "Terms of service not accepted",
+		Locales: errors.LocalisedString{
+			"fr": "Conditions non acceptées",
+		},
+	})
+
+	got := grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	f, ok := errors.AsPreconditionFailure(got)
+	if !ok {
+		t.Fatalf("expect a PreconditionFailure, but got %v", got)
+	}
+	v := f.Violations[0]
+	if msg := v.LocalizedDescription(language.French); msg != "Conditions non acceptées" {
+		t.Errorf("expect the French description, but got %q", msg)
+	}
+	// A violation without a matching translation keeps its description
+	if msg := v.LocalizedDescription(); msg == "" {
+		t.Error("expect a non-empty description")
+	}
+}
+
+func TestLocale_PackWithContext(t *testing.T) {
+	sent := errors.WithLocalizedMessage(
+		errors.WithLocalizedMessage(
+			errors.FailedPrecondition(),
+			language.French, "Conditions non acceptées",
+		),
+		language.German, "Bedingungen nicht akzeptiert",
+	)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"accept-language", "fr-CH, fr;q=0.9, en;q=0.8",
+	))
+
+	got := grpcerrors.Unpack(grpcerrors.PackWithContext(ctx, sent).Err())
+	if msg := errors.LocalizedMessage(got); msg != "Conditions non acceptées" {
+		t.Errorf("expect only the French message to survive, but got %q", msg)
+	}
+	if msg := errors.LocalizedMessage(got, language.German); msg != "Conditions non acceptées" {
+		t.Errorf("expect the German message to be dropped, but got %q", msg)
+	}
+}
@@ -0,0 +1,31 @@
+package grpcerrors
+
+import (
+	"github.com/deixis/errors"
+	"google.golang.org/grpc/status"
+)
+
+// sentinelStatuses holds pre-packed statuses for the shared sentinel
+// errors, which carry no details and always pack to the same status, so
+// packing them over and over on hot paths is wasted work.
+var sentinelStatuses = map[error]*status.Status{}
+
+func init() {
+	for _, err := range []error{
+		errors.NotFound,
+		errors.PermissionDenied,
+		errors.Unauthenticated,
+		errors.DataLoss,
+	} {
+		s, _ := pack(err)
+		sentinelStatuses[err] = s
+	}
+}
+
+// cachedStatus returns the pre-packed status for a sentinel error. A Status
+// is immutable (Proto returns a clone), so the cached value is shared
+// without copying.
+func cachedStatus(err error) (*status.Status, bool) {
+	s, ok := sentinelStatuses[err]
+	return s, ok
+}
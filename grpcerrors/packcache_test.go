@@ -0,0 +1,61 @@
+package grpcerrors_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPack_SentinelCache(t *testing.T) {
+	a := grpcerrors.Pack(errors.NotFound)
+	b := grpcerrors.Pack(errors.NotFound)
+	if a.Code() != codes.NotFound || b.Code() != codes.NotFound {
+		t.Errorf("expect code NotFound, but got %v and %v", a.Code(), b.Code())
+	}
+
+	// Attaching details to one copy must not leak into the next
+	if _, err := a.WithDetails(grpcerrors.ToRPCStatus(errors.DataLoss)); err != nil {
+		t.Fatalf("expect to attach a detail, but got %v", err)
+	}
+	if got := len(b.Proto().Details); got != 0 {
+		t.Errorf("expect an untouched status, but got %d details", got)
+	}
+
+	// The cache is bypassed at a redaction boundary
+	grpcerrors.Boundary = []errors.RedactPolicy{errors.StripDebug}
+	defer func() { grpcerrors.Boundary = nil }()
+	if s := grpcerrors.Pack(errors.NotFound); s.Code() != codes.NotFound {
+		t.Errorf("expect code NotFound, but got %v", s.Code())
+	}
+}
+
+func BenchmarkPack_Sentinel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		grpcerrors.Pack(errors.NotFound)
+	}
+}
+
+func BenchmarkUnpack_NoDetails(b *testing.B) {
+	err := grpcerrors.Pack(errors.PermissionDenied).Err()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grpcerrors.Unpack(err)
+	}
+}
+
+func BenchmarkRoundTrip_Quota(b *testing.B) {
+	sent := errors.ResourceExhausted(
+		&errors.QuotaViolation{
+			Subject:     "project:x/read",
+			Description: "Daily limit exceeded",
+		},
+		errors.WithRetryInfo(time.Minute),
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grpcerrors.Unpack(grpcerrors.Pack(sent).Err())
+	}
+}
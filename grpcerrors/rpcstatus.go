@@ -0,0 +1,27 @@
+package grpcerrors
+
+import (
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+)
+
+// ToRPCStatus converts err to the google.rpc.Status wire proto, independent
+// of a live gRPC call, so errors can be embedded in long-running operation
+// results, Pub/Sub messages and stored job outcomes. It returns nil when err
+// is nil.
+func ToRPCStatus(err error) *spb.Status {
+	if err == nil {
+		return nil
+	}
+	return Pack(err).Proto()
+}
+
+// FromRPCStatus reconstructs the error packed into a google.rpc.Status
+// proto by ToRPCStatus or by another google.rpc aware producer. It returns
+// nil when st is nil or carries codes.OK.
+func FromRPCStatus(st *spb.Status) error {
+	if st == nil {
+		return nil
+	}
+	return Unpack(status.FromProto(st).Err())
+}
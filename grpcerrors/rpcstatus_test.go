@@ -0,0 +1,87 @@
+package grpcerrors_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	anypb "github.com/golang/protobuf/ptypes/any"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+)
+
+func TestRPCStatus_RoundTrip(t *testing.T) {
+	sent := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	})
+
+	st := grpcerrors.ToRPCStatus(sent)
+	if st.Code != int32(code.Code_INVALID_ARGUMENT) {
+		t.Errorf("expect code %d, but got %d", code.Code_INVALID_ARGUMENT, st.Code)
+	}
+
+	got := grpcerrors.FromRPCStatus(st)
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	if f.Violations[0].Field != "email" {
+		t.Errorf("expect the field violation, but got %v", f.Violations)
+	}
+}
+
+func TestRPCStatus_RetryInfo(t *testing.T) {
+	sent := errors.Unavailable(30 * time.Second)
+	got := grpcerrors.FromRPCStatus(grpcerrors.ToRPCStatus(sent))
+	if d := errors.RetryAfter(got); d != 30*time.Second {
+		t.Errorf("expect retry delay of 30s, but got %v", d)
+	}
+}
+
+func TestRPCStatus_AlreadyExists(t *testing.T) {
+	sent := errors.AlreadyExists("user:42")
+
+	got := grpcerrors.FromRPCStatus(grpcerrors.ToRPCStatus(sent))
+	f, ok := errors.AsExistsFailure(got)
+	if !ok {
+		t.Fatalf("expect an ExistsFailure, but got %v", got)
+	}
+	if f.Resource != "user:42" {
+		t.Errorf("expect resource user:42, but got %q", f.Resource)
+	}
+}
+
+func TestRPCStatus_UnknownDetails(t *testing.T) {
+	// A detail type this binary cannot decode must survive the
+	// Unpack/Pack roundtrip untouched, so proxies stay transparent
+	unknown := &anypb.Any{
+		TypeUrl: "type.googleapis.com/acme.FrobnicationDetail",
+		Value:   []byte{0x0a, 0x03, 0x66, 0x6f, 0x6f},
+	}
+	st := &spb.Status{
+		Code:    int32(code.Code_FAILED_PRECONDITION),
+		Message: "frobnication failed",
+		Details: []*anypb.Any{unknown},
+	}
+
+	got := grpcerrors.ToRPCStatus(grpcerrors.FromRPCStatus(st))
+	for _, d := range got.Details {
+		if d.TypeUrl == unknown.TypeUrl && bytes.Equal(d.Value, unknown.Value) {
+			return
+		}
+	}
+	t.Errorf("expect the unknown detail to survive, but got %v", got.Details)
+}
+
+func TestRPCStatus_Nil(t *testing.T) {
+	if st := grpcerrors.ToRPCStatus(nil); st != nil {
+		t.Errorf("expect nil status, but got %v", st)
+	}
+	if err := grpcerrors.FromRPCStatus(nil); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
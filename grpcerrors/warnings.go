@@ -0,0 +1,43 @@
+package grpcerrors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/deixis/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// WarningTrailer is the metadata key under which warnings travel. The -bin
+// suffix makes gRPC base64-encode the JSON payload.
+const WarningTrailer = "x-errors-warning-bin"
+
+// PackWarnings sets the warnings collected on ctx (see
+// errors.CollectWarnings) as a trailer on the server stream, so clients
+// receive partial-degradation conditions without the call failing. It is a
+// no-op when no warning was raised.
+func PackWarnings(ctx context.Context) error {
+	warnings := errors.Warnings(ctx)
+	if len(warnings) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(warnings)
+	if err != nil {
+		return err
+	}
+	return grpc.SetTrailer(ctx, metadata.Pairs(WarningTrailer, string(data)))
+}
+
+// UnpackWarnings extracts the warnings carried by trailer metadata,
+// typically collected on the client with grpc.Trailer(&md)
+func UnpackWarnings(md metadata.MD) []*errors.Warning {
+	var warnings []*errors.Warning
+	for _, v := range md.Get(WarningTrailer) {
+		var batch []*errors.Warning
+		if err := json.Unmarshal([]byte(v), &batch); err == nil {
+			warnings = append(warnings, batch...)
+		}
+	}
+	return warnings
+}
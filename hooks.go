@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Hook observes a typed failure right after it has been constructed. Hooks
+// run synchronously on the constructing goroutine, so they should be cheap
+// (increment a counter, enqueue for reporting) and must not mutate the error.
+type Hook func(err error)
+
+// HookOption configures a registered hook
+type HookOption interface {
+	applyHook(e *hookEntry)
+}
+
+type hookOptionFunc func(e *hookEntry)
+
+func (f hookOptionFunc) applyHook(e *hookEntry) { f(e) }
+
+// SampleEvery returns an option that fires the hook on one construction out
+// of every n, starting with the first. It keeps high-volume hooks (e.g.
+// Sentry reporting) affordable on hot paths. n < 1 is treated as 1.
+func SampleEvery(n int) HookOption {
+	return hookOptionFunc(func(e *hookEntry) {
+		if n < 1 {
+			n = 1
+		}
+		e.rate = uint64(n)
+	})
+}
+
+type hookEntry struct {
+	fn   Hook
+	rate uint64
+	seen uint64
+}
+
+var hookRegistry = struct {
+	mu      sync.RWMutex
+	entries []*hookEntry
+}{}
+
+// RegisterHook registers a hook invoked whenever a typed failure is
+// constructed, so metrics, log enrichment or error reporting can be
+// centralised instead of wrapping every constructor at call sites.
+//
+// Hooks fire on construction only; errors rebuilt from the wire (JSON,
+// protobuf, transport unpacking) do not trigger them. Hooks cannot be
+// unregistered, so they are best registered from init functions.
+func RegisterHook(fn Hook, opts ...HookOption) {
+	e := &hookEntry{fn: fn, rate: 1}
+	for _, opt := range opts {
+		opt.applyHook(e)
+	}
+	hookRegistry.mu.Lock()
+	hookRegistry.entries = append(hookRegistry.entries, e)
+	hookRegistry.mu.Unlock()
+}
+
+// notify passes err to the registered hooks and returns it unchanged
+func notify(err error) error {
+	hookRegistry.mu.RLock()
+	entries := hookRegistry.entries
+	hookRegistry.mu.RUnlock()
+
+	for _, e := range entries {
+		if atomic.AddUint64(&e.seen, 1)%e.rate == 1 || e.rate == 1 {
+			e.fn(err)
+		}
+	}
+	return err
+}
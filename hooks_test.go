@@ -0,0 +1,46 @@
+package errors_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestRegisterHook(t *testing.T) {
+	var calls uint64
+	var last error
+	errors.RegisterHook(func(err error) {
+		atomic.AddUint64(&calls, 1)
+		last = err
+	})
+
+	before := atomic.LoadUint64(&calls)
+	err := errors.Aborted(&errors.ConflictViolation{Resource: "users/123"})
+	if got := atomic.LoadUint64(&calls) - before; got != 1 {
+		t.Errorf("expect 1 call, but got %d", got)
+	}
+	if last != err {
+		t.Errorf("expect hook to observe the constructed error, but got %v", last)
+	}
+
+	before = atomic.LoadUint64(&calls)
+	errors.WithNotFound(errors.New("no such user"))
+	if got := atomic.LoadUint64(&calls) - before; got != 1 {
+		t.Errorf("expect 1 call, but got %d", got)
+	}
+}
+
+func TestRegisterHook_SampleEvery(t *testing.T) {
+	var calls uint64
+	errors.RegisterHook(func(err error) {
+		atomic.AddUint64(&calls, 1)
+	}, errors.SampleEvery(3))
+
+	for i := 0; i < 6; i++ {
+		errors.Bad()
+	}
+	if got := atomic.LoadUint64(&calls); got != 2 {
+		t.Errorf("expect 2 calls, but got %d", got)
+	}
+}
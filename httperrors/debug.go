@@ -0,0 +1,56 @@
+package httperrors
+
+import (
+	"os"
+
+	"github.com/deixis/errors"
+)
+
+// Debug, when enabled, embeds the cause chain, attached stack traces, and
+// the node name into the JSON body of 5xx responses. Staging environments
+// turn it on to diagnose failures from the response alone; production must
+// keep it off.
+var Debug bool
+
+// debugBody is the debug object embedded into 5xx responses when Debug is
+// enabled
+type debugBody struct {
+	// Causes lists the messages of the wrap chain, outermost first
+	Causes []string `json:"causes,omitempty"`
+	// Stack carries the stack entries of the DebugInfo attached to the
+	// error, when any
+	Stack []string `json:"stack,omitempty"`
+	// Node is the hostname of the node that produced the error
+	Node string `json:"node,omitempty"`
+}
+
+var hostname, _ = os.Hostname()
+
+// packDebug builds the debug object for err
+func packDebug(err error) *debugBody {
+	d := &debugBody{Node: hostname}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		d.Causes = append(d.Causes, e.Error())
+	}
+	if info := debugInfoOf(err); info != nil {
+		d.Stack = info.StackEntries
+	}
+	return d
+}
+
+// debugInfoOf returns the DebugInfo carried by err, either attached as a
+// detail or embedded in a DataLossFailure or InternalFailure
+func debugInfoOf(err error) *errors.DebugInfo {
+	for _, d := range errors.Details(err) {
+		if info, ok := d.(*errors.DebugInfo); ok {
+			return info
+		}
+	}
+	if f, ok := errors.AsDataLossFailure(err); ok && f.DebugInfo != nil {
+		return f.DebugInfo
+	}
+	if f, ok := errors.AsInternalFailure(err); ok && f.DebugInfo != nil {
+		return f.DebugInfo
+	}
+	return nil
+}
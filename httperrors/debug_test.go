@@ -0,0 +1,62 @@
+package httperrors_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestDebug(t *testing.T) {
+	httperrors.Debug = true
+	defer func() { httperrors.Debug = false }()
+
+	err := errors.Recover("boom")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := httperrors.Marshal(req, rec, err); err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+
+	var body struct {
+		Error struct {
+			Debug struct {
+				Causes []string `json:"causes"`
+				Stack  []string `json:"stack"`
+			} `json:"debug"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("expect a JSON body, but got %v", err)
+	}
+	if len(body.Error.Debug.Causes) == 0 {
+		t.Error("expect the cause chain in the debug object")
+	}
+	if len(body.Error.Debug.Stack) == 0 {
+		t.Error("expect the stack trace in the debug object")
+	}
+}
+
+func TestDebug_Off(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := httperrors.Marshal(req, rec, errors.Recover("boom")); err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+
+	var body struct {
+		Error struct {
+			Debug *json.RawMessage `json:"debug"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("expect a JSON body, but got %v", err)
+	}
+	if body.Error.Debug != nil {
+		t.Error("expect no debug object when Debug is off")
+	}
+}
@@ -4,29 +4,48 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"time"
 
 	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/text/language"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
 )
 
-// Marshal marshals `err` to the HTTP response writer
+// Marshal marshals `err` to the HTTP response writer. When the error
+// carries localised messages, the translation matching the request locale
+// (see errors.LocaleFromContext and the Accept-Language header) becomes the
+// message, the internal message moves to `detail` for operators, and the
+// matched locale is announced in the Content-Language response header.
 func Marshal(r *http.Request, w http.ResponseWriter, err error) error {
-	status := Pack(err)
-	w.Header().Add("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(status.Code())
+	return marshalStatus(r, w, err, Pack(err))
+}
+
+func marshalStatus(r *http.Request, w http.ResponseWriter, err error, status *Status) error {
+	localise(r, err, status)
+	if Debug && err != nil && status.Code() >= 500 {
+		status.statusError.Debug = packDebug(err)
+	}
+	if Sanitise && err != nil && status.Code() >= 500 {
+		sanitise(err, status)
+	}
 
 	h := w.Header()
+	h.Add("Content-Type", "application/json; charset=utf-8")
 	for k, v := range status.Header {
 		for i := range v {
 			h.Add(k, v[i])
 		}
 	}
+	w.WriteHeader(status.Code())
 
 	// TODO: Load encoder
-	// TODO: Load Accept-Language
 
 	enc := json.NewEncoder(w)
 	return enc.Encode(struct {
@@ -43,29 +62,81 @@ func Unmarshal(w *http.Response) error {
 		// can't represent them at the moment.
 		//
 		// errors.NotFound could be appropriate.
-		return nil
+		//
+		// A streaming handler can still fail after a successful status; the
+		// failure then travels in the trailers (see MarshalTrailer), which
+		// are populated once the caller has consumed the body.
+		return UnmarshalTrailer(w)
 	}
 
 	defer w.Body.Close()
-	body, _ := ioutil.ReadAll(w.Body) // Ignore errors
+	body := readBody(w)
+	return applyHeaderMap(w.Header, unmarshalBody(w, body))
+}
+
+// unmarshalBody reconstructs the error described by the response body
+func unmarshalBody(w *http.Response, body []byte) error {
+	// A response produced by Marshal carries the canonical envelope, which
+	// reconstructs every failure and detail losslessly. The per-status
+	// mapping below remains for responses of other producers.
+	var envelope struct {
+		Error struct {
+			Raw json.RawMessage `json:"raw"`
+		} `json:"error"`
+	}
+	if jsonErr := json.Unmarshal(body, &envelope); jsonErr == nil && len(envelope.Error.Raw) > 0 {
+		if uerr, jsonErr := errors.UnmarshalJSON(envelope.Error.Raw); jsonErr == nil {
+			return uerr
+		}
+	}
+
+	// Proxy error pages carry no structured payload; keep a snippet of the
+	// page and classify by status
+	if isTextMedia(w) {
+		return unmarshalText(w, body)
+	}
+
+	// Proto-first gateways ship a google.rpc.Status proto as the body
+	if isProtoMedia(w) {
+		st := &spb.Status{}
+		if protoErr := proto.Unmarshal(body, st); protoErr == nil && st.Code != 0 {
+			return grpcerrors.FromRPCStatus(st)
+		}
+	}
+
+	// Gateway-fronted gRPC services ship the same status as JSON
+	if uerr, ok := unmarshalGateway(body); ok {
+		return uerr
+	}
 
 	switch w.StatusCode {
-	case http.StatusGatewayTimeout:
-		return context.DeadlineExceeded
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return errors.WithDeadlineExceeded(context.DeadlineExceeded)
+	case http.StatusNotImplemented:
+		return errors.WithNotImplemented(errors.New(w.Status))
+	case http.StatusInternalServerError:
+		switch unmarshalErrorReason(body) {
+		case "DATA_LOSS":
+			return errors.WithDataLoss(errors.New(w.Status))
+		case "INTERNAL":
+			return &errors.InternalFailure{}
+		}
 	case http.StatusServiceUnavailable:
-		d, _ := parseRetryAfter(w.Header)
+		d, _ := ParseRetryAfter(w.Header)
 		return errors.Unavailable(d)
-	case http.StatusForbidden:
+	case http.StatusForbidden, http.StatusUnavailableForLegalReasons:
 		return errors.PermissionDenied
 	case http.StatusUnauthorized:
 		return errors.Unauthenticated
 	case http.StatusNotFound:
 		return errors.NotFound
-	case http.StatusBadRequest:
+	case http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType:
+		return errors.Bad()
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
 		failure := errdetails.BadRequest{}
 		pickUnmarshaller(w)(body, &failure)
 
-		violations := make([]*errors.FieldViolation, len(failure.FieldViolations))
+		violations := make([]errors.Option, len(failure.FieldViolations))
 		for i, violation := range failure.FieldViolations {
 			violations[i] = &errors.FieldViolation{
 				Field:       violation.Field,
@@ -73,11 +144,11 @@ func Unmarshal(w *http.Response) error {
 			}
 		}
 		return errors.Bad(violations...)
-	case http.StatusPreconditionFailed:
+	case http.StatusPreconditionFailed, http.StatusPreconditionRequired:
 		failure := errdetails.PreconditionFailure{}
 		pickUnmarshaller(w)(body, &failure)
 
-		violations := make([]*errors.PreconditionViolation, len(failure.Violations))
+		violations := make([]errors.Option, len(failure.Violations))
 		for i, violation := range failure.Violations {
 			violations[i] = &errors.PreconditionViolation{
 				Type:        violation.Type,
@@ -85,30 +156,152 @@ func Unmarshal(w *http.Response) error {
 				Description: violation.Description,
 			}
 		}
+		if len(violations) == 0 && w.StatusCode == http.StatusPreconditionRequired {
+			// Keep the 428 semantic even when the body carries no detail
+			violations = append(violations, &errors.PreconditionViolation{
+				Type: PreconditionRequiredType,
+			})
+		}
 		return errors.FailedPrecondition(violations...)
+	case http.StatusLocked:
+		return errors.FailedPrecondition()
 	case http.StatusConflict:
-		return errors.Aborted()
+		if resource, ok := unmarshalResourceInfo(body); ok {
+			return errors.AlreadyExists(resource)
+		}
+		return errors.Aborted(unmarshalConflictViolations(body)...)
 	case http.StatusTooManyRequests:
 		failure := errdetails.QuotaFailure{}
 		pickUnmarshaller(w)(body, &failure)
 
-		violations := make([]*errors.QuotaViolation, len(failure.Violations))
+		opts := make([]errors.Option, len(failure.Violations))
 		for i, violation := range failure.Violations {
-			violations[i] = &errors.QuotaViolation{
+			opts[i] = &errors.QuotaViolation{
 				Subject:     violation.Subject,
 				Description: violation.Description,
 			}
 		}
-		return errors.ResourceExhausted(violations...)
+		if d, ok := ParseRetryAfter(w.Header); ok && d > 0 {
+			opts = append(opts, errors.WithRetryInfo(d))
+		}
+		return errors.ResourceExhausted(opts...)
 	}
 
 	return errors.New(w.Status)
 }
 
+// unmarshalConflictViolations recovers the conflict violations attached to
+// a 409 body produced by Marshal
+func unmarshalConflictViolations(body []byte) []errors.Option {
+	var envelope struct {
+		Error struct {
+			Details []struct {
+				ConflictViolations []struct {
+					Resource    string `json:"resource"`
+					Description string `json:"description"`
+				} `json:"conflictViolations"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if jsonErr := json.Unmarshal(body, &envelope); jsonErr != nil {
+		return nil
+	}
+
+	var violations []errors.Option
+	for _, d := range envelope.Error.Details {
+		for _, v := range d.ConflictViolations {
+			violations = append(violations, &errors.ConflictViolation{
+				Resource:    v.Resource,
+				Description: v.Description,
+			})
+		}
+	}
+	return violations
+}
+
+// unmarshalResourceInfo recovers the resource name attached to a 409 body
+// describing an already existing entity
+func unmarshalResourceInfo(body []byte) (string, bool) {
+	var envelope struct {
+		Error struct {
+			Details []struct {
+				ResourceInfo struct {
+					ResourceName string `json:"resourceName"`
+				} `json:"resourceInfo"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if jsonErr := json.Unmarshal(body, &envelope); jsonErr != nil {
+		return "", false
+	}
+	for _, d := range envelope.Error.Details {
+		if d.ResourceInfo.ResourceName != "" {
+			return d.ResourceInfo.ResourceName, true
+		}
+	}
+	return "", false
+}
+
+// unmarshalErrorReason recovers the errorInfo reason attached to a 500 body
+// produced by Marshal, keeping HTTP parity with the gRPC code set
+func unmarshalErrorReason(body []byte) string {
+	var envelope struct {
+		Error struct {
+			Details []struct {
+				ErrorInfo struct {
+					Reason string `json:"reason"`
+				} `json:"errorInfo"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if jsonErr := json.Unmarshal(body, &envelope); jsonErr != nil {
+		return ""
+	}
+	for _, d := range envelope.Error.Details {
+		if d.ErrorInfo.Reason != "" {
+			return d.ErrorInfo.Reason
+		}
+	}
+	return ""
+}
+
+// MaxBodySize caps how much of an error response body Unmarshal reads, so
+// a misbehaving upstream cannot make clients buffer an arbitrarily large
+// "error". The default leaves room for debug envelopes carrying stack
+// traces. A truncated body simply falls back to the per-status mapping.
+var MaxBodySize int64 = 64 << 10
+
+// readBody reads the response body up to MaxBodySize, honouring a smaller
+// Content-Length when the upstream declares one
+func readBody(w *http.Response) []byte {
+	limit := MaxBodySize
+	if limit <= 0 {
+		limit = 64 << 10
+	}
+	if w.ContentLength > 0 && w.ContentLength < limit {
+		limit = w.ContentLength
+	}
+	body, _ := ioutil.ReadAll(io.LimitReader(w.Body, limit)) // Ignore errors
+	return body
+}
+
+// Boundary lists the redaction policies applied to every error packed by
+// this transport. Services facing external clients typically set it to
+// errors.StripInternal and errors.StripDebug at start-up.
+var Boundary []errors.RedactPolicy
+
 // Pack returns a Status representing err if it was produced from an
 // `*errors.Error` struct.
 func Pack(err error) *Status {
+	if len(Boundary) > 0 {
+		err = errors.Redact(err, Boundary...)
+	} else if s, ok := cachedStatus(err); ok {
+		// Sentinels never change; skip re-packing them on hot paths
+		overrideStatus(s, err, nil)
+		return s
+	}
 	s, _ := pack(err)
+	overrideStatus(s, err, nil)
 	return s
 }
 
@@ -116,6 +309,33 @@ func Pack(err error) *Status {
 // `*errors.Error` struct. Otherwise, ok is false and a Status is returned
 // with http.StatusInternalServerError and the original error message.
 func pack(err error) (*Status, bool) {
+	s, ok := packStatus(err)
+	if t := errors.Time(err); !t.IsZero() {
+		s.statusError.Time = &t
+	}
+	if details := errors.Details(err); len(details) > 0 {
+		s.statusError.Details = append(s.statusError.Details, details...)
+	}
+	if msg := errors.UserMessage(err); msg != "" {
+		// Clients see the user message; logs keep the full internal chain
+		s.statusError.Message = msg
+	}
+	if d := errors.RetryAfter(err); d > 0 &&
+		s.statusError.Code == http.StatusTooManyRequests &&
+		s.Header.Get(retryAfter) == "" {
+		// A RetryInfo attached above the quota failure still becomes a
+		// backoff hint
+		formatRetryAfter(s.Header, d)
+	}
+	if err != nil {
+		// The canonical envelope makes the roundtrip lossless for Go
+		// consumers; the typed details remain for everyone else
+		s.statusError.Raw, _ = errors.MarshalJSON(err)
+	}
+	return s, ok
+}
+
+func packStatus(err error) (*Status, bool) {
 	if err == nil {
 		return New(http.StatusOK, ""), true
 	}
@@ -126,9 +346,62 @@ func pack(err error) (*Status, bool) {
 	}
 
 	switch err := err.(type) {
+	case *errors.MultiFailure:
+		if len(err.Errors) == 0 {
+			return New(http.StatusInternalServerError, err.Error()), false
+		}
+		// The status carries the code of the first member, but the details
+		// of every member
+		s, ok := pack(err.Errors[0])
+		s.statusError.Message = err.Error()
+		for _, member := range err.Errors[1:] {
+			ms, _ := pack(member)
+			s.statusError.Details = append(s.statusError.Details, ms.statusError.Details...)
+		}
+		return s, ok
+	case *errors.TimeoutFailure, *errors.CancelledFailure:
+		return New(http.StatusGatewayTimeout, err.Error()), true
+	case *errors.NotImplementedFailure:
+		return New(http.StatusNotImplemented, err.Error()), true
 	case *errors.AvailabilityFailure:
 		s := New(http.StatusServiceUnavailable, err.Error())
 		formatRetryAfter(s.Header, err.RetryInfo.RetryDelay)
+		if err.RetryInfo.RetryDelay > 0 {
+			s.statusError.Details = append(s.statusError.Details, map[string]interface{}{
+				"retryInfo": map[string]string{
+					"retryDelay": err.RetryInfo.RetryDelay.String(),
+				},
+			})
+		}
+		if err.Reason != errors.ReasonUnspecified {
+			s.statusError.Details = append(s.statusError.Details, map[string]interface{}{
+				"availabilityReason": err.Reason.String(),
+			})
+		}
+		return s, true
+	case *errors.DataLossFailure:
+		s := New(http.StatusInternalServerError, err.Error())
+		s.statusError.Details = []interface{}{map[string]interface{}{
+			"errorInfo": map[string]string{"reason": "DATA_LOSS"},
+		}}
+		if err.DebugInfo != nil {
+			s.statusError.Details = append(s.statusError.Details, &errdetails.DebugInfo{
+				StackEntries: err.DebugInfo.StackEntries,
+				Detail:       err.DebugInfo.Detail,
+			})
+		}
+		return s, true
+	case *errors.InternalFailure:
+		s := New(http.StatusInternalServerError, err.Error())
+		s.statusError.Details = []interface{}{map[string]interface{}{
+			"errorInfo": map[string]string{"reason": "INTERNAL"},
+		}}
+		if err.DebugInfo != nil {
+			s.statusError.Details = append(s.statusError.Details, &errdetails.DebugInfo{
+				StackEntries: err.DebugInfo.StackEntries,
+				Detail:       err.DebugInfo.Detail,
+			})
+		}
 		return s, true
 	case *errors.PermissionFailure:
 		return New(http.StatusForbidden, err.Error()), true
@@ -147,10 +420,26 @@ func pack(err error) (*Status, bool) {
 				Description: violation.Description,
 			}
 		}
-		s.Details = []interface{}{detail}
+		s.statusError.Details = []interface{}{detail}
+		if meta := packViolationMeta(err.Violations); meta != nil {
+			s.statusError.Details = append(s.statusError.Details, meta)
+		}
+		return s, true
+	case *errors.OutOfRangeFailure:
+		s := New(http.StatusBadRequest, err.Error())
+		detail := &errdetails.BadRequest{
+			FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(err.Violations)),
+		}
+		for i, violation := range err.Violations {
+			detail.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description(),
+			}
+		}
+		s.statusError.Details = []interface{}{detail}
 		return s, true
 	case *errors.PreconditionFailure:
-		s := New(http.StatusPreconditionFailed, err.Error())
+		s := New(preconditionStatus(err.Violations), err.Error())
 		detail := &errdetails.PreconditionFailure{
 			Violations: make([]*errdetails.PreconditionFailure_Violation, len(err.Violations)),
 		}
@@ -161,24 +450,69 @@ func pack(err error) (*Status, bool) {
 				Description: violation.Description,
 			}
 		}
-		s.Details = []interface{}{detail}
+		s.statusError.Details = []interface{}{detail}
 		return s, true
 	case *errors.ConflictFailure:
-		return New(http.StatusConflict, err.Error()), true
+		s := New(http.StatusConflict, err.Error())
+		if len(err.Violations) > 0 {
+			violations := make([]map[string]string, len(err.Violations))
+			for i, violation := range err.Violations {
+				violations[i] = map[string]string{
+					"resource":    violation.Resource,
+					"description": violation.Description,
+				}
+			}
+			s.statusError.Details = []interface{}{map[string]interface{}{
+				"conflictViolations": violations,
+			}}
+		}
+		return s, true
+	case *errors.ExistsFailure:
+		s := New(http.StatusConflict, err.Error())
+		if err.Resource != "" {
+			s.statusError.Details = []interface{}{map[string]interface{}{
+				"resourceInfo": map[string]string{
+					"resourceName": err.Resource,
+				},
+			}}
+		}
+		return s, true
 	case *errors.QuotaFailure:
 		s := New(http.StatusTooManyRequests, err.Error())
 		detail := &errdetails.QuotaFailure{
 			Violations: make([]*errdetails.QuotaFailure_Violation, len(err.Violations)),
 		}
+		var reset time.Time
 		for i, violation := range err.Violations {
 			detail.Violations[i] = &errdetails.QuotaFailure_Violation{
 				Subject:     violation.Subject,
 				Description: violation.Description,
 			}
+			if violation.ResetTime.After(reset) {
+				reset = violation.ResetTime
+			}
+		}
+		s.statusError.Details = []interface{}{detail}
+		if meta := packQuotaMeta(err.Violations); meta != nil {
+			s.statusError.Details = append(s.statusError.Details, meta)
+		}
+		delay := errors.RetryAfter(err)
+		if !reset.IsZero() {
+			delay = reset.Sub(Now())
+		}
+		if delay > 0 {
+			formatRetryAfter(s.Header, delay)
+			s.statusError.Details = append(s.statusError.Details, map[string]interface{}{
+				"retryInfo": map[string]string{
+					"retryDelay": delay.String(),
+				},
+			})
 		}
-		s.Details = []interface{}{detail}
 		return s, true
 	default:
+		if cause := errors.Unwrap(err); cause != nil {
+			return packStatus(cause)
+		}
 		return New(http.StatusInternalServerError, err.Error()), false
 	}
 }
@@ -205,6 +539,23 @@ func (s *Status) Message() string {
 	return s.statusError.Message
 }
 
+// Details returns a copy of the details attached to s.
+func (s *Status) Details() []interface{} {
+	if s == nil {
+		return nil
+	}
+	return append([]interface{}(nil), s.statusError.Details...)
+}
+
+// WithDetails returns a new Status with the given details appended, leaving
+// s untouched. It lets middleware attach extra context (e.g. a RequestInfo)
+// before the response is written.
+func (s *Status) WithDetails(details ...interface{}) *Status {
+	c := &Status{s.statusError}
+	c.statusError.Details = append(s.Details(), details...)
+	return c
+}
+
 // Err returns an immutable error representing s; returns nil if s.Code() is OK.
 func (s *Status) Err() error {
 	if s.Code() == http.StatusOK {
@@ -224,10 +575,15 @@ func Newf(code int, format string, a ...interface{}) *Status {
 }
 
 type statusError struct {
-	Code    int           `json:"-"`
-	Header  http.Header   `json:"-"`
-	Message string        `json:"message"`
-	Details []interface{} `json:"details,omitempty"`
+	Code             int             `json:"-"`
+	Header           http.Header     `json:"-"`
+	Message          string          `json:"message"`
+	Detail           string          `json:"detail,omitempty"`
+	LocalizedMessage string          `json:"localizedMessage,omitempty"`
+	Time             *time.Time      `json:"time,omitempty"`
+	Details          []interface{}   `json:"details,omitempty"`
+	Debug            *debugBody      `json:"debug,omitempty"`
+	Raw              json.RawMessage `json:"raw,omitempty"`
 }
 
 func (se *statusError) Error() string {
@@ -244,6 +600,99 @@ func (se *statusError) HTTPStatus() *Status {
 	return &Status{*se}
 }
 
+// packViolationMeta returns a detail carrying the structured metadata
+// (reason, expected, actual) of the given violations, or nil when none of
+// them has any
+func packViolationMeta(violations []*errors.FieldViolation) interface{} {
+	meta := map[string]map[string]string{}
+	for _, v := range violations {
+		if v.Reason == "" && v.Expected == "" && v.Actual == "" {
+			continue
+		}
+		m := map[string]string{}
+		if v.Reason != "" {
+			m["reason"] = v.Reason
+		}
+		if v.Expected != "" {
+			m["expected"] = v.Expected
+		}
+		if v.Actual != "" {
+			m["actual"] = v.Actual
+		}
+		meta[v.Field] = m
+	}
+	if len(meta) == 0 {
+		return nil
+	}
+	return map[string]interface{}{"fieldViolationMeta": meta}
+}
+
+// packQuotaMeta returns a detail carrying the structured metadata (limit,
+// current, reset time) of the given violations, or nil when none of them
+// has any
+func packQuotaMeta(violations []*errors.QuotaViolation) interface{} {
+	meta := map[string]map[string]interface{}{}
+	for _, v := range violations {
+		if v.Limit == 0 && v.Current == 0 && v.ResetTime.IsZero() {
+			continue
+		}
+		m := map[string]interface{}{}
+		if v.Limit != 0 {
+			m["limit"] = v.Limit
+		}
+		if v.Current != 0 {
+			m["current"] = v.Current
+		}
+		if !v.ResetTime.IsZero() {
+			m["resetTime"] = v.ResetTime.Format(time.RFC3339Nano)
+		}
+		meta[v.Subject] = m
+	}
+	if len(meta) == 0 {
+		return nil
+	}
+	return map[string]interface{}{"quotaViolationMeta": meta}
+}
+
+// localise resolves the localised message of `err` that best matches the
+// request locale and promotes it to the status message, keeping only the
+// matched translation in the details. The internal message moves to the
+// `detail` field, so operators keep the full chain.
+func localise(r *http.Request, err error, status *Status) {
+	preferred := errors.LocaleFromContext(r.Context())
+	if len(preferred) == 0 {
+		tags, _, parseErr := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		if parseErr != nil {
+			return
+		}
+		preferred = tags
+	}
+	msg := errors.LocalizedMessage(err, preferred...)
+	if msg == "" {
+		return
+	}
+	status.statusError.LocalizedMessage = msg
+
+	var matched language.Tag
+	details := status.statusError.Details[:0]
+	for _, d := range status.statusError.Details {
+		if m, ok := d.(*errors.LocalisedMessage); ok {
+			if m.Message != msg {
+				continue
+			}
+			matched = m.Locale
+		}
+		details = append(details, d)
+	}
+	status.statusError.Details = details
+
+	status.statusError.Detail = status.statusError.Message
+	status.statusError.Message = msg
+	if matched != language.Und {
+		status.Header.Set("Content-Language", matched.String())
+	}
+}
+
 type unmarshaller func(data []byte, v interface{}) error
 
 var nopUnmarshaller = func(data []byte, v interface{}) error { return nil }
@@ -261,6 +710,25 @@ func pickUnmarshaller(w *http.Response) unmarshaller {
 	switch mtype {
 	case "application/json":
 		return json.Unmarshal
+	case "application/x-protobuf", "application/protobuf":
+		return protoUnmarshaller
 	}
 	return nopUnmarshaller
 }
+
+// protoUnmarshaller decodes bodies holding a bare errdetails message
+var protoUnmarshaller = func(data []byte, v interface{}) error {
+	if m, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(data, m)
+	}
+	return nil
+}
+
+// isProtoMedia reports whether the response body is protobuf encoded
+func isProtoMedia(w *http.Response) bool {
+	mtype, _, err := mime.ParseMediaType(w.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mtype == "application/x-protobuf" || mtype == "application/protobuf"
+}
@@ -0,0 +1,218 @@
+package httperrors_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestHTTP_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "bad request",
+			err: errors.Bad(&errors.FieldViolation{
+				Field:       "email",
+				Description: "Invalid email address",
+			}),
+		},
+		{
+			name: "failed precondition",
+			err: errors.FailedPrecondition(&errors.PreconditionViolation{
+				Type:        "TOS",
+				Subject:     "auth.service",
+				Description: "Terms of service not accepted",
+			}),
+		},
+		{
+			name: "conflict",
+			err: errors.Aborted(&errors.ConflictViolation{
+				Resource:    "bookings/42",
+				Description: "Booking was modified concurrently",
+			}),
+		},
+		{
+			name: "quota",
+			err: errors.ResourceExhausted(&errors.QuotaViolation{
+				Subject:     "project:x/read",
+				Description: "Daily limit exceeded",
+			}),
+		},
+		{
+			name: "out of range",
+			err: errors.OutOfRange(&errors.RangeViolation{
+				Field: "page", Min: 1, Max: 100, Actual: 250,
+			}),
+		},
+		{name: "unavailable", err: errors.Unavailable(30 * time.Second)},
+		{
+			name: "unavailable with reason",
+			err:  errors.UnavailableBecause(errors.ReasonMaintenance, time.Minute),
+		},
+		{name: "not found", err: errors.NotFound},
+		{name: "already exists", err: errors.AlreadyExists("user:42")},
+		{name: "not implemented", err: errors.WithNotImplemented(errors.New("todo"))},
+		{name: "permission denied", err: errors.PermissionDenied},
+		{name: "unauthenticated", err: errors.Unauthenticated},
+		{
+			name: "with details",
+			err: errors.Aborted(
+				errors.WithMetadata(map[string]string{"shard": "eu-1"}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if err := httperrors.Marshal(req, rec, test.err); err != nil {
+				t.Fatalf("expect to marshal error, but got %v", err)
+			}
+
+			got := httperrors.Unmarshal(rec.Result())
+			if !errors.Match(got, test.err) {
+				t.Errorf("expect %v, but got %v", test.err, got)
+			}
+		})
+	}
+}
+
+func TestHTTP_WithDetails(t *testing.T) {
+	s := httperrors.Pack(errors.Aborted())
+	base := len(s.Details())
+
+	extra := s.WithDetails(map[string]interface{}{"requestId": "req-1"})
+	if got := len(extra.Details()); got != base+1 {
+		t.Errorf("expect %d details, but got %d", base+1, got)
+	}
+	if got := len(s.Details()); got != base {
+		t.Errorf("expect the original status to be untouched, but got %d details", got)
+	}
+}
+
+func TestHTTP_StatusMapping(t *testing.T) {
+	// Responses of foreign producers carry no envelope, so Unmarshal falls
+	// back to the status code
+	tests := []struct {
+		status int
+		code   errors.Code
+	}{
+		{status: http.StatusRequestTimeout, code: errors.CodeDeadlineExceeded},
+		{status: http.StatusRequestEntityTooLarge, code: errors.CodeInvalidArgument},
+		{status: http.StatusUnsupportedMediaType, code: errors.CodeInvalidArgument},
+		{status: http.StatusUnprocessableEntity, code: errors.CodeInvalidArgument},
+		{status: http.StatusLocked, code: errors.CodeFailedPrecondition},
+		{status: http.StatusUnavailableForLegalReasons, code: errors.CodePermissionDenied},
+		{status: http.StatusNotImplemented, code: errors.CodeUnimplemented},
+	}
+
+	for _, test := range tests {
+		t.Run(http.StatusText(test.status), func(t *testing.T) {
+			res := &http.Response{
+				StatusCode: test.status,
+				Status:     http.StatusText(test.status),
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}
+			got := httperrors.Unmarshal(res)
+			if code := errors.CodeOf(got); code != test.code {
+				t.Errorf("expect code %v, but got %v", test.code, code)
+			}
+		})
+	}
+}
+
+func TestHTTP_ErrorReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		code   errors.Code
+	}{
+		{reason: "DATA_LOSS", code: errors.CodeDataLoss},
+		{reason: "INTERNAL", code: errors.CodeInternal},
+	}
+
+	for _, test := range tests {
+		t.Run(test.reason, func(t *testing.T) {
+			body := `{"error":{"message":"boom","details":[{"errorInfo":{"reason":"` +
+				test.reason + `"}}]}}`
+			res := &http.Response{
+				StatusCode:    http.StatusInternalServerError,
+				Status:        http.StatusText(http.StatusInternalServerError),
+				ContentLength: int64(len(body)),
+				Header:        http.Header{"Content-Type": []string{"application/json"}},
+				Body:          ioutil.NopCloser(strings.NewReader(body)),
+			}
+			got := httperrors.Unmarshal(res)
+			if code := errors.CodeOf(got); code != test.code {
+				t.Errorf("expect code %v, but got %v", test.code, code)
+			}
+		})
+	}
+}
+
+func TestHTTP_QuotaRetryAfter(t *testing.T) {
+	sent := errors.ResourceExhausted(
+		&errors.QuotaViolation{
+			Subject:     "project:x/read",
+			Description: "Daily limit exceeded",
+		},
+		errors.WithRetryInfo(time.Minute),
+	)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := httperrors.Marshal(req, rec, sent); err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+
+	res := rec.Result()
+	if h := res.Header.Get("Retry-After"); h != "60" {
+		t.Errorf("expect Retry-After of 60s, but got %q", h)
+	}
+	got := httperrors.Unmarshal(res)
+	if !errors.IsResourceExhausted(got) {
+		t.Fatalf("expect a resource exhausted error, but got %v", got)
+	}
+	if d := errors.RetryAfter(got); d != time.Minute {
+		t.Errorf("expect retry delay of 1m, but got %v", d)
+	}
+}
+
+func TestHTTP_BodyLimit(t *testing.T) {
+	body := `{"error":{"message":"` + strings.Repeat("x", 1<<20) + `"}}`
+	res := &http.Response{
+		StatusCode:    http.StatusServiceUnavailable,
+		ContentLength: int64(len(body)),
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+	}
+
+	got := httperrors.Unmarshal(res)
+	if !errors.IsUnavailable(got) {
+		t.Errorf("expect a truncated body to fall back to the status mapping, but got %v", got)
+	}
+}
+
+func TestHTTP_RoundTrip_RetryDelay(t *testing.T) {
+	sent := errors.Unavailable(30 * time.Second)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := httperrors.Marshal(req, rec, sent); err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+
+	got := httperrors.Unmarshal(rec.Result())
+	if d := errors.RetryAfter(got); d != 30*time.Second {
+		t.Errorf("expect retry delay of 30s, but got %v", d)
+	}
+}
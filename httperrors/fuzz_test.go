@@ -0,0 +1,56 @@
+package httperrors_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/deixis/errors/httperrors"
+)
+
+// FuzzUnmarshal hammers the decoder that runs on every upstream failure
+// with malformed bodies, wrong content types, and hostile header values.
+// Whatever comes in, Unmarshal must return a typed error without panicking.
+func FuzzUnmarshal(f *testing.F) {
+	f.Add(400, "application/json", "", `{"error":{"message":"boom"}}`)
+	f.Add(400, "application/json", "", `{"error":{"raw":{"type":"badRequest"}}}`)
+	f.Add(429, "application/json", "banana", `{"violations":[{"subject":"s"}]}`)
+	f.Add(503, "text/html", "-1", "<html><body>oops</body></html>")
+	f.Add(500, "application/x-protobuf", "", "\x08\x03\x12\x04boom")
+	f.Add(404, "application/json", "999999999999999999999", `{"code":5,"message":"nope"}`)
+	f.Add(409, "application/json", "", `{"error":{"details":[{"conflictViolations":42}]}}`)
+
+	f.Fuzz(func(t *testing.T, status int, ctype, retry, body string) {
+		status = 400 + (status%200+200)%200
+		res := &http.Response{
+			StatusCode:    status,
+			Status:        http.StatusText(status),
+			ContentLength: int64(len(body)),
+			Header: http.Header{
+				"Content-Type": []string{ctype},
+				"Retry-After":  []string{retry},
+			},
+			Body: ioutil.NopCloser(strings.NewReader(body)),
+		}
+
+		got := httperrors.Unmarshal(res)
+		if got == nil {
+			t.Errorf("expect an error for status %d, but got nil", status)
+		}
+	})
+}
+
+// FuzzUnmarshalEvent exercises the server-sent event parser with arbitrary
+// blocks
+func FuzzUnmarshalEvent(f *testing.F) {
+	f.Add("event: error\ndata: {\"type\":\"notFound\"}")
+	f.Add("event: message\ndata: {}")
+	f.Add("data: no event name")
+
+	f.Fuzz(func(t *testing.T, block string) {
+		if got, ok := httperrors.UnmarshalEvent(block); ok && got == nil {
+			t.Error("expect a non-nil error when ok is true")
+		}
+	})
+}
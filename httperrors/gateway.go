@@ -0,0 +1,22 @@
+package httperrors
+
+import (
+	"bytes"
+
+	"github.com/deixis/errors/grpcerrors"
+	"github.com/golang/protobuf/jsonpb"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// unmarshalGateway recognises the grpc-gateway error envelope — the JSON
+// form of a google.rpc.Status ({"code":n,"message":...,"details":[{"@type":
+// ...}]}) — and maps it back into typed failures. It reports false when the
+// body is in another shape.
+func unmarshalGateway(body []byte) (error, bool) {
+	st := &spb.Status{}
+	u := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := u.Unmarshal(bytes.NewReader(body), st); err != nil || st.Code == 0 {
+		return nil, false
+	}
+	return grpcerrors.FromRPCStatus(st), true
+}
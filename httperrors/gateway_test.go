@@ -0,0 +1,62 @@
+package httperrors_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func gatewayResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode:    status,
+		ContentLength: int64(len(body)),
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestUnmarshal_Gateway(t *testing.T) {
+	body := `{
+		"code": 3,
+		"message": "invalid email",
+		"details": [{
+			"@type": "type.googleapis.com/google.rpc.BadRequest",
+			"fieldViolations": [
+				{"field": "email", "description": "Invalid email address"}
+			]
+		}]
+	}`
+
+	got := httperrors.Unmarshal(gatewayResponse(http.StatusBadRequest, body))
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	if f.Violations[0].Field != "email" {
+		t.Errorf("expect the field violation, but got %v", f.Violations)
+	}
+}
+
+func TestUnmarshal_Gateway_RetryInfo(t *testing.T) {
+	body := `{
+		"code": 14,
+		"message": "try again later",
+		"details": [{
+			"@type": "type.googleapis.com/google.rpc.RetryInfo",
+			"retryDelay": "30s"
+		}]
+	}`
+
+	got := httperrors.Unmarshal(gatewayResponse(http.StatusServiceUnavailable, body))
+	if !errors.IsUnavailable(got) {
+		t.Fatalf("expect an unavailable error, but got %v", got)
+	}
+	if d := errors.RetryAfter(got); d != 30*time.Second {
+		t.Errorf("expect retry delay of 30s, but got %v", d)
+	}
+}
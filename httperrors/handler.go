@@ -0,0 +1,46 @@
+package httperrors
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/spine/log"
+)
+
+// HandlerFunc is an HTTP handler that returns an error instead of writing
+// the failure to the response itself
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Handler adapts fn to http.Handler. A returned error is logged via the
+// context logger and marshalled with Marshal, so plain net/http services
+// get the same error responses as the rest of the stack.
+func Handler(fn HandlerFunc) http.Handler {
+	return fn
+}
+
+// ServeHTTP implements http.Handler
+func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := fn(w, r)
+	if err == nil {
+		return
+	}
+
+	// A request torn down by the client or its deadline surfaces as the
+	// matching typed failure, unless the handler already returned one
+	if ctxErr := r.Context().Err(); ctxErr != nil && errors.CodeOf(err) == errors.CodeUnknown {
+		switch ctxErr {
+		case context.Canceled:
+			err = errors.WithCanceled(err)
+		case context.DeadlineExceeded:
+			err = errors.WithDeadlineExceeded(err)
+		}
+	}
+
+	log.Err(r.Context(), "http.handler.err", "Request failed", log.Error(err))
+	if mErr := Marshal(r, w, err); mErr != nil {
+		log.Err(r.Context(), "http.handler.marshal.err", "Cannot marshal error",
+			log.Error(mErr),
+		)
+	}
+}
@@ -0,0 +1,45 @@
+package httperrors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestHandler(t *testing.T) {
+	h := httperrors.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.Bad(&errors.FieldViolation{
+			Field:       "email",
+			Description: "Invalid email address",
+		})
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	res := rec.Result()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expect status 400, but got %d", res.StatusCode)
+	}
+	got := httperrors.Unmarshal(res)
+	if !errors.IsBad(got) {
+		t.Errorf("expect a bad request, but got %v", got)
+	}
+}
+
+func TestHandler_OK(t *testing.T) {
+	h := httperrors.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expect status 204, but got %d", rec.Code)
+	}
+}
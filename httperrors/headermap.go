@@ -0,0 +1,69 @@
+package httperrors
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/deixis/errors"
+)
+
+// HeaderMapper enriches an error unmarshalled from a response with the
+// value of one of its headers
+type HeaderMapper func(err error, value string) error
+
+var (
+	headerMapMu sync.RWMutex
+	headerMap   = map[string]HeaderMapper{}
+)
+
+// MapHeader registers a mapper for the given response header, so transport
+// metadata isn't lost when the body lacks structured details. The mapper
+// runs during Unmarshal whenever the header is present. Registering a
+// header again replaces its mapper.
+//
+// X-Request-Id and X-RateLimit-Reset are mapped out of the box.
+func MapHeader(name string, fn HeaderMapper) {
+	headerMapMu.Lock()
+	headerMap[http.CanonicalHeaderKey(name)] = fn
+	headerMapMu.Unlock()
+}
+
+// applyHeaderMap runs the registered header mappers against err
+func applyHeaderMap(h http.Header, err error) error {
+	headerMapMu.RLock()
+	defer headerMapMu.RUnlock()
+	for name, fn := range headerMap {
+		if value := h.Get(name); value != "" {
+			err = fn(err, value)
+		}
+	}
+	return err
+}
+
+func init() {
+	MapHeader("X-Request-Id", func(err error, value string) error {
+		// Attach the identifier as a Correlation detail, like the gRPC
+		// unpack path, so errors.CorrelationID can find it
+		return errors.WithDetails(err, &errors.Correlation{
+			ID: value,
+		})
+	})
+	MapHeader("X-RateLimit-Reset", func(err error, value string) error {
+		// The header carries the reset time as Unix epoch seconds
+		n, parseErr := strconv.ParseInt(value, 10, 64)
+		if parseErr != nil {
+			return err
+		}
+		reset := time.Unix(n, 0)
+		if f, ok := errors.AsQuotaFailure(err); ok {
+			for _, v := range f.Violations {
+				if v.ResetTime.IsZero() {
+					v.ResetTime = reset
+				}
+			}
+		}
+		return err
+	})
+}
@@ -0,0 +1,60 @@
+package httperrors_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestHeaderMap_RequestID(t *testing.T) {
+	res := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header: http.Header{
+			"X-Request-Id": []string{"req-42"},
+		},
+		Body: ioutil.NopCloser(strings.NewReader("")),
+	}
+
+	got := httperrors.Unmarshal(res)
+	if id := errors.CorrelationID(got); id != "req-42" {
+		t.Errorf("expect correlation ID req-42, but got %q", id)
+	}
+}
+
+func TestHeaderMap_RateLimitReset(t *testing.T) {
+	sent := errors.ResourceExhausted(&errors.QuotaViolation{
+		Subject:     "project:x/read",
+		Description: "Daily limit exceeded",
+	})
+	raw, err := errors.MarshalJSON(sent)
+	if err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+	body := `{"error":{"raw":` + string(raw) + `}}`
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	res := &http.Response{
+		StatusCode:    http.StatusTooManyRequests,
+		ContentLength: int64(len(body)),
+		Header: http.Header{
+			"Content-Type":      []string{"application/json"},
+			"X-Ratelimit-Reset": []string{strconv.FormatInt(reset.Unix(), 10)},
+		},
+		Body: ioutil.NopCloser(strings.NewReader(body)),
+	}
+
+	got := httperrors.Unmarshal(res)
+	f, ok := errors.AsQuotaFailure(got)
+	if !ok {
+		t.Fatalf("expect a quota failure, but got %v", got)
+	}
+	if !f.Violations[0].ResetTime.Equal(reset) {
+		t.Errorf("expect reset time %v, but got %v", reset, f.Violations[0].ResetTime)
+	}
+}
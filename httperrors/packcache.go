@@ -0,0 +1,36 @@
+package httperrors
+
+import (
+	"github.com/deixis/errors"
+)
+
+// sentinelStatuses holds the pre-packed statuses of the immutable
+// sentinels, so hot paths calling Pack on every failed request skip the
+// envelope marshalling
+var sentinelStatuses = map[error]*Status{}
+
+func init() {
+	for _, sentinel := range []error{
+		errors.NotFound,
+		errors.PermissionDenied,
+		errors.Unauthenticated,
+		errors.DataLoss,
+	} {
+		s, _ := pack(sentinel)
+		sentinelStatuses[sentinel] = s
+	}
+}
+
+// cachedStatus returns a mutable copy of the pre-packed status for err, if
+// it is one of the sentinels. The copy keeps Marshal free to localise or
+// sanitise without corrupting the table.
+func cachedStatus(err error) (*Status, bool) {
+	s, ok := sentinelStatuses[err]
+	if !ok {
+		return nil, false
+	}
+	c := &Status{s.statusError}
+	c.statusError.Header = s.statusError.Header.Clone()
+	c.statusError.Details = append([]interface{}(nil), s.statusError.Details...)
+	return c, true
+}
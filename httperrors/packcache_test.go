@@ -0,0 +1,32 @@
+package httperrors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestPack_SentinelCache(t *testing.T) {
+	a := httperrors.Pack(errors.NotFound)
+	b := httperrors.Pack(errors.NotFound)
+	if a == b {
+		t.Fatal("expect Pack to return independent statuses")
+	}
+
+	// Mutating one copy must not leak into the next
+	a.Header.Set("Content-Language", "fr")
+	if got := b.Header.Get("Content-Language"); got != "" {
+		t.Errorf("expect an untouched header, but got %q", got)
+	}
+
+	if a.Code() != b.Code() || a.Message() != b.Message() {
+		t.Errorf("expect identical statuses, but got %v and %v", a, b)
+	}
+}
+
+func BenchmarkPack_Sentinel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		httperrors.Pack(errors.NotFound)
+	}
+}
@@ -0,0 +1,34 @@
+package httperrors
+
+import (
+	"net/http"
+
+	"github.com/deixis/errors"
+)
+
+// PreconditionRequiredType is the violation type signalling that a request
+// lacked a required conditional header (e.g. If-Match). It maps to 428
+// Precondition Required, whereas any other precondition failure maps to 412
+// Precondition Failed.
+const PreconditionRequiredType = "PRECONDITION_REQUIRED"
+
+// PreconditionRequired builds the failure for a request missing a
+// conditional header, e.g. an update endpoint mandating If-Match
+func PreconditionRequired(subject, description string) error {
+	return errors.FailedPrecondition(&errors.PreconditionViolation{
+		Type:        PreconditionRequiredType,
+		Subject:     subject,
+		Description: description,
+	})
+}
+
+// preconditionStatus returns 428 when one of the violations reports a
+// missing conditional header, and 412 otherwise
+func preconditionStatus(violations []*errors.PreconditionViolation) int {
+	for _, v := range violations {
+		if v.Type == PreconditionRequiredType {
+			return http.StatusPreconditionRequired
+		}
+	}
+	return http.StatusPreconditionFailed
+}
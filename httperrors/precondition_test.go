@@ -0,0 +1,52 @@
+package httperrors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestPrecondition_RequiredVsFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "required",
+			err:    httperrors.PreconditionRequired("If-Match", "Conditional update required"),
+			status: http.StatusPreconditionRequired,
+		},
+		{
+			name: "failed",
+			err: errors.FailedPrecondition(&errors.PreconditionViolation{
+				Type:        "ETAG",
+				Subject:     "If-Match",
+				Description: "Resource was modified",
+			}),
+			status: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/", nil)
+			if err := httperrors.Marshal(req, rec, test.err); err != nil {
+				t.Fatalf("expect to marshal error, but got %v", err)
+			}
+
+			res := rec.Result()
+			if res.StatusCode != test.status {
+				t.Errorf("expect status %d, but got %d", test.status, res.StatusCode)
+			}
+			got := httperrors.Unmarshal(res)
+			if !errors.Match(got, test.err) {
+				t.Errorf("expect %v, but got %v", test.err, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package problem emits and parses RFC 7807 application/problem+json
+// payloads, for consumers that mandate that format over the default
+// httperrors envelope. Violations travel as an extension member.
+package problem
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+	"github.com/deixis/errors/registry"
+)
+
+// ContentType is the RFC 7807 media type
+const ContentType = "application/problem+json"
+
+// Problem is the RFC 7807 representation of an error
+type Problem struct {
+	// Type is a URI reference identifying the problem type. It defaults to
+	// "about:blank", or the documentation URL of the registered definition
+	// (see registry.Register) when the error carries one.
+	Type string `json:"type"`
+	// Title is a short, human-readable summary of the problem type
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code
+	Status int `json:"status,omitempty"`
+	// Detail is a human-readable explanation specific to this occurrence
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference identifying this occurrence, e.g. the
+	// correlation ID of the request that produced the error
+	Instance string `json:"instance,omitempty"`
+
+	// Violations is an extension member carrying the violations attached to
+	// the error
+	Violations []*Violation `json:"violations,omitempty"`
+}
+
+// Violation is the flattened form of the violation types of this module
+type Violation struct {
+	Field       string `json:"field,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Subject     string `json:"subject,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// From builds the RFC 7807 representation of err
+func From(err error) *Problem {
+	status := httperrors.Pack(err)
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status.Code()),
+		Status: status.Code(),
+		Detail: status.Message(),
+	}
+	if def, ok := registry.Code(err); ok && def.DocsURL != "" {
+		p.Type = def.DocsURL
+	}
+	if id := errors.CorrelationID(err); id != "" {
+		p.Instance = id
+	}
+
+	if f, ok := errors.AsBadRequest(err); ok {
+		for _, v := range f.Violations {
+			p.Violations = append(p.Violations, &Violation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+	}
+	if f, ok := errors.AsPreconditionFailure(err); ok {
+		for _, v := range f.Violations {
+			p.Violations = append(p.Violations, &Violation{
+				Type:        v.Type,
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+	}
+	if f, ok := errors.AsConflictFailure(err); ok {
+		for _, v := range f.Violations {
+			p.Violations = append(p.Violations, &Violation{
+				Resource:    v.Resource,
+				Description: v.Description,
+			})
+		}
+	}
+	if f, ok := errors.AsQuotaFailure(err); ok {
+		for _, v := range f.Violations {
+			p.Violations = append(p.Violations, &Violation{
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+	}
+	return p
+}
+
+// Marshal marshals `err` to the HTTP response writer as
+// application/problem+json
+func Marshal(r *http.Request, w http.ResponseWriter, err error) error {
+	status := httperrors.Pack(err)
+	p := From(err)
+
+	h := w.Header()
+	h.Set("Content-Type", ContentType)
+	for k, v := range status.Header {
+		for i := range v {
+			h.Add(k, v[i])
+		}
+	}
+	w.WriteHeader(p.Status)
+
+	return json.NewEncoder(w).Encode(p)
+}
+
+// Unmarshal extracts the error carried by an application/problem+json
+// response. Responses in another media type are delegated to
+// httperrors.Unmarshal.
+func Unmarshal(res *http.Response) error {
+	if res.StatusCode < 400 {
+		return nil
+	}
+	if mt, _, err := mime.ParseMediaType(res.Header.Get("Content-Type")); err != nil || mt != ContentType {
+		return httperrors.Unmarshal(res)
+	}
+
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body) // Ignore errors
+
+	p := &Problem{}
+	if err := json.Unmarshal(body, p); err != nil {
+		return errors.Errorf("http error: code = %d", res.StatusCode)
+	}
+	return p.Err(res.Header)
+}
+
+// Err reconstructs the error described by p. The response header carries
+// the retry delay of 503 responses.
+func (p *Problem) Err(header http.Header) error {
+	switch p.Status {
+	case http.StatusBadRequest:
+		violations := make([]errors.Option, len(p.Violations))
+		for i, v := range p.Violations {
+			violations[i] = &errors.FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			}
+		}
+		return errors.Bad(violations...)
+	case http.StatusPreconditionFailed:
+		violations := make([]errors.Option, len(p.Violations))
+		for i, v := range p.Violations {
+			violations[i] = &errors.PreconditionViolation{
+				Type:        v.Type,
+				Subject:     v.Subject,
+				Description: v.Description,
+			}
+		}
+		return errors.FailedPrecondition(violations...)
+	case http.StatusConflict:
+		violations := make([]errors.Option, len(p.Violations))
+		for i, v := range p.Violations {
+			violations[i] = &errors.ConflictViolation{
+				Resource:    v.Resource,
+				Description: v.Description,
+			}
+		}
+		return errors.Aborted(violations...)
+	case http.StatusTooManyRequests:
+		violations := make([]errors.Option, len(p.Violations))
+		for i, v := range p.Violations {
+			violations[i] = &errors.QuotaViolation{
+				Subject:     v.Subject,
+				Description: v.Description,
+			}
+		}
+		return errors.ResourceExhausted(violations...)
+	case http.StatusServiceUnavailable:
+		d, _ := httperrors.ParseRetryAfter(header)
+		return errors.Unavailable(d)
+	case http.StatusGatewayTimeout:
+		return errors.WithDeadlineExceeded(errors.New(p.Detail))
+	case http.StatusForbidden:
+		return errors.PermissionDenied
+	case http.StatusUnauthorized:
+		return errors.Unauthenticated
+	case http.StatusNotFound:
+		return errors.NotFound
+	}
+	if p.Detail != "" {
+		return errors.New(p.Detail)
+	}
+	return errors.Errorf("http error: code = %d", p.Status)
+}
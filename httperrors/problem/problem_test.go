@@ -0,0 +1,70 @@
+package problem_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors/problem"
+)
+
+func TestProblem_RoundTrip(t *testing.T) {
+	sent := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	if err := problem.Marshal(req, rec, sent); err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+
+	res := rec.Result()
+	if ct := res.Header.Get("Content-Type"); ct != problem.ContentType {
+		t.Errorf("expect content type %s, but got %s", problem.ContentType, ct)
+	}
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expect status 400, but got %d", res.StatusCode)
+	}
+
+	got := problem.Unmarshal(res)
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	if f.Violations[0].Field != "email" {
+		t.Errorf("expect the field violation, but got %v", f.Violations)
+	}
+}
+
+func TestProblem_From(t *testing.T) {
+	err := errors.FailedPrecondition(&errors.PreconditionViolation{
+		Type:        "TOS",
+		Subject:     "auth.service",
+		Description: "Terms of service not accepted",
+	})
+
+	p := problem.From(err)
+	if p.Type != "about:blank" {
+		t.Errorf("expect type about:blank, but got %s", p.Type)
+	}
+	if p.Status != http.StatusPreconditionFailed {
+		t.Errorf("expect status 412, but got %d", p.Status)
+	}
+	if len(p.Violations) != 1 || p.Violations[0].Subject != "auth.service" {
+		t.Errorf("expect the precondition violation, but got %v", p.Violations)
+	}
+}
+
+func TestProblem_Unmarshal_Fallback(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json; charset=utf-8")
+	rec.WriteHeader(http.StatusNotFound)
+
+	got := problem.Unmarshal(rec.Result())
+	if !errors.IsNotFound(got) {
+		t.Errorf("expect a not found error, but got %v", got)
+	}
+}
@@ -0,0 +1,58 @@
+package httperrors_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/grpcerrors"
+	"github.com/deixis/errors/httperrors"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func protoResponse(status int, m proto.Message) *http.Response {
+	data, _ := proto.Marshal(m)
+	return &http.Response{
+		StatusCode:    status,
+		ContentLength: int64(len(data)),
+		Header:        http.Header{"Content-Type": []string{"application/x-protobuf"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(data)),
+	}
+}
+
+func TestUnmarshal_ProtoStatus(t *testing.T) {
+	sent := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	})
+	res := protoResponse(http.StatusBadRequest, grpcerrors.ToRPCStatus(sent))
+
+	got := httperrors.Unmarshal(res)
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	if f.Violations[0].Field != "email" {
+		t.Errorf("expect the field violation, but got %v", f.Violations)
+	}
+}
+
+func TestUnmarshal_ProtoDetails(t *testing.T) {
+	res := protoResponse(http.StatusBadRequest, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "email", Description: "Invalid email address"},
+		},
+	})
+
+	got := httperrors.Unmarshal(res)
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	if f.Violations[0].Field != "email" {
+		t.Errorf("expect the field violation, but got %v", f.Violations)
+	}
+}
@@ -0,0 +1,43 @@
+package httperrors
+
+import (
+	"net/http"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/spine/log"
+	"github.com/deixis/spine/stats"
+)
+
+// Recoverer returns a middleware that catches panics from the next
+// handler, converts them via errors.Recover into an InternalFailure with
+// DebugInfo, and emits a 500 through Marshal. Each recovered panic is
+// logged with its stack and counted on the `http.panic` stat.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				// net/http uses this sentinel to abort the connection
+				// silently; turning it into a 500 would change its semantics
+				panic(recovered)
+			}
+			err := errors.Recover(recovered)
+
+			log.Err(r.Context(), "http.panic", "Recovered from panic",
+				log.Error(err),
+			)
+			stats.Inc(r.Context(), "http.panic")
+
+			if mErr := Marshal(r, w, err); mErr != nil {
+				log.Err(r.Context(), "http.panic.marshal.err", "Cannot marshal error",
+					log.Error(mErr),
+				)
+			}
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,54 @@
+package httperrors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestRecoverer(t *testing.T) {
+	h := httperrors.Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	res := rec.Result()
+	if res.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expect status 500, but got %d", res.StatusCode)
+	}
+	got := httperrors.Unmarshal(res)
+	if _, ok := errors.AsInternalFailure(got); !ok {
+		t.Errorf("expect an internal failure, but got %v", got)
+	}
+}
+
+func TestRecoverer_AbortHandler(t *testing.T) {
+	h := httperrors.Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if recover() != http.ErrAbortHandler {
+			t.Error("expect http.ErrAbortHandler to be re-panicked")
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestRecoverer_NoPanic(t *testing.T) {
+	h := httperrors.Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("expect status 418, but got %d", rec.Code)
+	}
+}
@@ -13,10 +13,10 @@ const retryAfter = "Retry-After"
 // Now returns the current time
 var Now = time.Now
 
-// parseRetryAfter parses the `Retry-After` header and returns its duration.
+// ParseRetryAfter parses the `Retry-After` header and returns its duration.
 // If it does not exist or can't be parsed, it will return 0. The values
 // returned are guaranteed to greater or equal to 0.
-func parseRetryAfter(h http.Header) (time.Duration, bool) {
+func ParseRetryAfter(h http.Header) (time.Duration, bool) {
 	s := h.Get(retryAfter)
 	if seconds, err := strconv.ParseInt(s, 10, 32); err == nil {
 		if seconds < 0 {
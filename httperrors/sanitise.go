@@ -0,0 +1,40 @@
+package httperrors
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/deixis/errors"
+)
+
+// Sanitise, when enabled, replaces the body of responses with a status
+// >= 500 by a generic message plus the correlation ID, so internal messages
+// (SQL fragments, file paths) never reach clients. The full error is handed
+// to SanitiseLog before the body is stripped. Sanitise takes precedence
+// over Debug.
+var Sanitise bool
+
+// SanitiseLog receives the full error before a 5xx body is sanitised, so
+// operators keep the internal chain. It defaults to the standard library
+// logger.
+var SanitiseLog = func(err error) {
+	log.Printf("httperrors: %v", err)
+}
+
+// sanitise strips the internal content from a 5xx status
+func sanitise(err error, status *Status) {
+	if SanitiseLog != nil {
+		SanitiseLog(err)
+	}
+	msg := "An internal error occurred"
+	if id := errors.CorrelationID(err); id != "" {
+		msg = fmt.Sprintf("%s (correlation %s)", msg, id)
+	}
+	se := &status.statusError
+	se.Message = msg
+	se.Detail = ""
+	se.LocalizedMessage = ""
+	se.Details = nil
+	se.Debug = nil
+	se.Raw = nil
+}
@@ -0,0 +1,60 @@
+package httperrors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestSanitise(t *testing.T) {
+	httperrors.Sanitise = true
+	defer func() { httperrors.Sanitise = false }()
+
+	var logged error
+	httperrors.SanitiseLog = func(err error) { logged = err }
+
+	err := errors.WithDataLoss(errors.New(
+		`pq: relation "users" does not exist in /srv/app/store.go`,
+	))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := httperrors.Marshal(req, rec, err); err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+
+	body := rec.Body.String()
+	if strings.Contains(body, "users") || strings.Contains(body, "store.go") {
+		t.Errorf("expect internal content to be stripped, but got %s", body)
+	}
+	if !strings.Contains(body, "An internal error occurred") {
+		t.Errorf("expect the generic message, but got %s", body)
+	}
+	if logged != err {
+		t.Errorf("expect the full error to be logged, but got %v", logged)
+	}
+}
+
+func TestSanitise_ClientErrors(t *testing.T) {
+	httperrors.Sanitise = true
+	defer func() { httperrors.Sanitise = false }()
+
+	err := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := httperrors.Marshal(req, rec, err); err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+
+	if !strings.Contains(rec.Body.String(), "email") {
+		t.Error("expect 4xx bodies to keep their violations")
+	}
+}
@@ -0,0 +1,55 @@
+package httperrors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/deixis/errors"
+)
+
+// MarshalEvent emits `err` as a server-sent "error" event, so long-lived
+// streaming endpoints can terminate with a typed error instead of dropping
+// the connection. The event data is the canonical envelope (see
+// errors.MarshalJSON), which UnmarshalEvent decodes on the client.
+func MarshalEvent(w http.ResponseWriter, err error) error {
+	if len(Boundary) > 0 {
+		err = errors.Redact(err, Boundary...)
+	}
+	data, mErr := errors.MarshalJSON(err)
+	if mErr != nil {
+		return mErr
+	}
+	if _, wErr := fmt.Fprintf(w, "event: error\ndata: %s\n\n", data); wErr != nil {
+		return wErr
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// UnmarshalEvent parses a server-sent event block — the lines between two
+// blank lines of an SSE stream — and returns the typed error it carries.
+// It reports false when the block is not an "error" event.
+func UnmarshalEvent(block string) (error, bool) {
+	var event string
+	var data []string
+	for _, line := range strings.Split(block, "\n") {
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if event != "error" || len(data) == 0 {
+		return nil, false
+	}
+
+	uerr, decodeErr := errors.UnmarshalJSON([]byte(strings.Join(data, "\n")))
+	if decodeErr != nil || uerr == nil {
+		return nil, false
+	}
+	return uerr, true
+}
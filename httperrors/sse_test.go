@@ -0,0 +1,42 @@
+package httperrors_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestMarshalEvent(t *testing.T) {
+	sent := errors.Unavailable(30 * time.Second)
+
+	rec := httptest.NewRecorder()
+	if err := httperrors.MarshalEvent(rec, sent); err != nil {
+		t.Fatalf("expect to marshal event, but got %v", err)
+	}
+
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "event: error\n") {
+		t.Errorf("expect an error event, but got %s", body)
+	}
+	if !strings.HasSuffix(body, "\n\n") {
+		t.Errorf("expect the event to be terminated by a blank line, but got %q", body)
+	}
+
+	got, ok := httperrors.UnmarshalEvent(strings.TrimSuffix(body, "\n\n"))
+	if !ok {
+		t.Fatal("expect an error event")
+	}
+	if !errors.Match(got, sent) {
+		t.Errorf("expect %v, but got %v", sent, got)
+	}
+}
+
+func TestUnmarshalEvent_OtherEvent(t *testing.T) {
+	if _, ok := httperrors.UnmarshalEvent("event: message\ndata: {}"); ok {
+		t.Error("expect non-error events to be skipped")
+	}
+}
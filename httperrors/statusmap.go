@@ -0,0 +1,60 @@
+package httperrors
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/deixis/errors"
+)
+
+// StatusMap maps canonical error codes to the HTTP status code to emit
+type StatusMap map[errors.Code]int
+
+var (
+	statusMapMu sync.RWMutex
+	statusMap   = StatusMap{}
+)
+
+// MapStatus overrides the HTTP status emitted for errors carrying the given
+// canonical code, e.g. 422 instead of 400 for invalid arguments. It applies
+// to every subsequent Pack and Marshal; use PackWith or MarshalWith for
+// per-call overrides.
+func MapStatus(code errors.Code, httpStatus int) {
+	statusMapMu.Lock()
+	statusMap[code] = httpStatus
+	statusMapMu.Unlock()
+}
+
+// PackWith is like Pack, but rewrites the HTTP status according to the
+// given per-call overrides. Overrides take precedence over the MapStatus
+// registry.
+func PackWith(err error, overrides StatusMap) *Status {
+	s := Pack(err)
+	overrideStatus(s, err, overrides)
+	return s
+}
+
+// MarshalWith is like Marshal, but rewrites the HTTP status according to
+// the given per-call overrides
+func MarshalWith(r *http.Request, w http.ResponseWriter, err error, overrides StatusMap) error {
+	return marshalStatus(r, w, err, PackWith(err, overrides))
+}
+
+// overrideStatus rewrites the status code of s according to the per-call
+// overrides and the MapStatus registry
+func overrideStatus(s *Status, err error, overrides StatusMap) {
+	if err == nil {
+		return
+	}
+	code := errors.CodeOf(err)
+	if httpStatus, ok := overrides[code]; ok {
+		s.statusError.Code = httpStatus
+		return
+	}
+	statusMapMu.RLock()
+	httpStatus, ok := statusMap[code]
+	statusMapMu.RUnlock()
+	if ok {
+		s.statusError.Code = httpStatus
+	}
+}
@@ -0,0 +1,49 @@
+package httperrors_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestPackWith(t *testing.T) {
+	err := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	})
+
+	s := httperrors.PackWith(err, httperrors.StatusMap{
+		errors.CodeInvalidArgument: http.StatusUnprocessableEntity,
+	})
+	if s.Code() != http.StatusUnprocessableEntity {
+		t.Errorf("expect status 422, but got %d", s.Code())
+	}
+
+	// Other codes keep the default mapping
+	s = httperrors.PackWith(errors.NotFound, httperrors.StatusMap{
+		errors.CodeInvalidArgument: http.StatusUnprocessableEntity,
+	})
+	if s.Code() != http.StatusNotFound {
+		t.Errorf("expect status 404, but got %d", s.Code())
+	}
+}
+
+func TestMapStatus(t *testing.T) {
+	httperrors.MapStatus(errors.CodeFailedPrecondition, http.StatusConflict)
+	defer httperrors.MapStatus(errors.CodeFailedPrecondition, http.StatusPreconditionFailed)
+
+	s := httperrors.Pack(errors.FailedPrecondition())
+	if s.Code() != http.StatusConflict {
+		t.Errorf("expect status 409, but got %d", s.Code())
+	}
+
+	// Per-call overrides take precedence over the registry
+	s = httperrors.PackWith(errors.FailedPrecondition(), httperrors.StatusMap{
+		errors.CodeFailedPrecondition: http.StatusUnprocessableEntity,
+	})
+	if s.Code() != http.StatusUnprocessableEntity {
+		t.Errorf("expect status 422, but got %d", s.Code())
+	}
+}
@@ -0,0 +1,85 @@
+package httperrors
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/deixis/errors"
+)
+
+// maxSnippetSize caps how much of a proxy error page survives in the error
+// message
+const maxSnippetSize = 120
+
+// isTextMedia reports whether the response body is an HTML or plaintext
+// error page, typically produced by an intermediate proxy
+func isTextMedia(w *http.Response) bool {
+	mtype, _, err := mime.ParseMediaType(w.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mtype == "text/html" || mtype == "text/plain"
+}
+
+// unmarshalText classifies a proxy error page by its status code, keeping a
+// truncated snippet of the page as the error message. Structured decoding
+// is pointless on these bodies.
+func unmarshalText(w *http.Response, body []byte) error {
+	msg := w.Status
+	if snippet := textSnippet(body); snippet != "" {
+		msg = fmt.Sprintf("%s: %s", w.Status, snippet)
+	}
+	cause := errors.New(msg)
+
+	switch w.StatusCode {
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return errors.WithDeadlineExceeded(cause)
+	case http.StatusServiceUnavailable:
+		d, _ := ParseRetryAfter(w.Header)
+		return errors.WithUnavailable(cause, d)
+	case http.StatusForbidden, http.StatusUnavailableForLegalReasons:
+		return errors.WithPermissionDenied(cause)
+	case http.StatusUnauthorized:
+		return errors.WithUnauthenticated(cause)
+	case http.StatusNotFound:
+		return errors.WithNotFound(cause)
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge,
+		http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		return errors.WithBad(cause)
+	case http.StatusPreconditionFailed, http.StatusLocked:
+		return errors.WithFailedPrecondition(cause)
+	case http.StatusConflict:
+		return errors.WithAborted(cause)
+	case http.StatusTooManyRequests:
+		return errors.WithResourceExhausted(cause)
+	case http.StatusNotImplemented:
+		return errors.WithNotImplemented(cause)
+	}
+	return cause
+}
+
+// textSnippet strips markup from an error page and truncates it to a
+// single-line snippet
+func textSnippet(body []byte) string {
+	var b strings.Builder
+	var inTag bool
+	for _, r := range string(body) {
+		switch {
+		case r == '<':
+			inTag = true
+			b.WriteRune(' ')
+		case r == '>':
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+
+	snippet := strings.Join(strings.Fields(b.String()), " ")
+	if len(snippet) > maxSnippetSize {
+		snippet = snippet[:maxSnippetSize] + "..."
+	}
+	return snippet
+}
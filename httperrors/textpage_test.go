@@ -0,0 +1,57 @@
+package httperrors_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestTextPage_HTML(t *testing.T) {
+	body := "<html><head><title>503</title></head>" +
+		"<body><h1>Service under maintenance</h1></body></html>"
+	res := &http.Response{
+		StatusCode:    http.StatusServiceUnavailable,
+		Status:        "503 Service Unavailable",
+		ContentLength: int64(len(body)),
+		Header:        http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+	}
+
+	got := httperrors.Unmarshal(res)
+	if !errors.IsUnavailable(got) {
+		t.Fatalf("expect an unavailable error, but got %v", got)
+	}
+	cause := errors.Unwrap(got)
+	if cause == nil {
+		t.Fatal("expect the page snippet as cause")
+	}
+	if !strings.Contains(cause.Error(), "Service under maintenance") {
+		t.Errorf("expect the page snippet in the message, but got %q", cause.Error())
+	}
+	if strings.Contains(cause.Error(), "<") {
+		t.Errorf("expect markup to be stripped, but got %q", cause.Error())
+	}
+}
+
+func TestTextPage_Plain(t *testing.T) {
+	body := strings.Repeat("upstream connect error ", 50)
+	res := &http.Response{
+		StatusCode:    http.StatusNotFound,
+		Status:        "404 Not Found",
+		ContentLength: int64(len(body)),
+		Header:        http.Header{"Content-Type": []string{"text/plain"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+	}
+
+	got := httperrors.Unmarshal(res)
+	if code := errors.CodeOf(got); code != errors.CodeNotFound {
+		t.Fatalf("expect code %v, but got %v", errors.CodeNotFound, code)
+	}
+	if len(got.Error()) > 200 {
+		t.Errorf("expect a truncated snippet, but got %d bytes", len(got.Error()))
+	}
+}
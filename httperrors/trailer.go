@@ -0,0 +1,43 @@
+package httperrors
+
+import (
+	"net/http"
+
+	"github.com/deixis/errors"
+)
+
+// ErrorTrailer is the trailer under which an error travels when it occurs
+// after the response body has started streaming
+const ErrorTrailer = "X-Errors-Error"
+
+// MarshalTrailer writes err into the response trailers. Handlers streaming
+// a chunked body cannot change the status once the first chunk is out;
+// the trailer lets them terminate the stream with a typed error instead of
+// silently dropping the connection. The error travels as the canonical
+// envelope (see errors.MarshalJSON).
+func MarshalTrailer(w http.ResponseWriter, err error) error {
+	if len(Boundary) > 0 {
+		err = errors.Redact(err, Boundary...)
+	}
+	data, mErr := errors.MarshalJSON(err)
+	if mErr != nil {
+		return mErr
+	}
+	w.Header().Set(http.TrailerPrefix+ErrorTrailer, string(data))
+	return nil
+}
+
+// UnmarshalTrailer returns the error carried by the response trailers, or
+// nil when there is none. Trailers only become available once the response
+// body has been fully consumed.
+func UnmarshalTrailer(res *http.Response) error {
+	v := res.Trailer.Get(ErrorTrailer)
+	if v == "" {
+		return nil
+	}
+	uerr, decodeErr := errors.UnmarshalJSON([]byte(v))
+	if decodeErr != nil {
+		return nil
+	}
+	return uerr
+}
@@ -0,0 +1,38 @@
+package httperrors_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+func TestTrailer_RoundTrip(t *testing.T) {
+	sent := errors.Unavailable(30 * time.Second)
+
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(http.StatusOK)
+	fmt.Fprint(rec, "partial stream...")
+	if err := httperrors.MarshalTrailer(rec, sent); err != nil {
+		t.Fatalf("expect to marshal trailer, but got %v", err)
+	}
+
+	got := httperrors.Unmarshal(rec.Result())
+	if !errors.Match(got, sent) {
+		t.Errorf("expect %v, but got %v", sent, got)
+	}
+}
+
+func TestTrailer_None(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(http.StatusOK)
+	fmt.Fprint(rec, "complete stream")
+
+	if got := httperrors.Unmarshal(rec.Result()); got != nil {
+		t.Errorf("expect no error, but got %v", got)
+	}
+}
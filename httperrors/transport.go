@@ -0,0 +1,66 @@
+package httperrors
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// Transport wraps an http.RoundTripper and turns error responses into
+// typed errors, so HTTP clients stop duplicating the Unmarshal logic:
+//
+//	client := &http.Client{Transport: &httperrors.Transport{}}
+//
+// On a status >= 400, the response body is consumed by Unmarshal, closed,
+// and the typed error is returned from RoundTrip. With AttachToResponse
+// set, the response is returned instead and the error is recovered with
+// ResponseError.
+type Transport struct {
+	// Base is the wrapped round tripper. It defaults to
+	// http.DefaultTransport.
+	Base http.RoundTripper
+
+	// AttachToResponse returns error responses to the caller with the typed
+	// error attached, instead of failing the round trip. Some clients need
+	// the raw response, e.g. to read headers of error responses.
+	AttachToResponse bool
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	res, err := base.RoundTrip(req)
+	if err != nil || res.StatusCode < 400 {
+		return res, err
+	}
+
+	uerr := Unmarshal(res) // Consumes and closes the body
+	if !t.AttachToResponse {
+		return nil, uerr
+	}
+	res.Body = &errorBody{Reader: bytes.NewReader(nil), err: uerr}
+	return res, nil
+}
+
+// ResponseError returns the typed error attached to an error response by a
+// Transport with AttachToResponse set. It returns nil for any other
+// response.
+func ResponseError(res *http.Response) error {
+	if b, ok := res.Body.(*errorBody); ok {
+		return b.err
+	}
+	return nil
+}
+
+// errorBody replaces the consumed body of an error response and carries
+// the typed error
+type errorBody struct {
+	io.Reader
+	err error
+}
+
+// Close implements io.Closer
+func (b *errorBody) Close() error { return nil }
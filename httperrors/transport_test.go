@@ -0,0 +1,81 @@
+package httperrors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func errorResponse(err error) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		if mErr := httperrors.Marshal(req, rec, err); mErr != nil {
+			return nil, mErr
+		}
+		return rec.Result(), nil
+	})
+}
+
+func TestTransport(t *testing.T) {
+	client := &http.Client{Transport: &httperrors.Transport{
+		Base: errorResponse(errors.NotFound),
+	}}
+
+	res, err := client.Get("http://service/users/42")
+	if res != nil {
+		t.Errorf("expect no response, but got %v", res)
+	}
+	if !errors.IsNotFound(errors.Unwrap(err)) {
+		// http.Client wraps transport errors in *url.Error
+		t.Errorf("expect a not found error, but got %v", err)
+	}
+}
+
+func TestTransport_Attach(t *testing.T) {
+	client := &http.Client{Transport: &httperrors.Transport{
+		Base:             errorResponse(errors.NotFound),
+		AttachToResponse: true,
+	}}
+
+	res, err := client.Get("http://service/users/42")
+	if err != nil {
+		t.Fatalf("expect the response, but got %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expect status 404, but got %d", res.StatusCode)
+	}
+	if !errors.IsNotFound(httperrors.ResponseError(res)) {
+		t.Errorf("expect a not found error, but got %v", httperrors.ResponseError(res))
+	}
+}
+
+func TestTransport_OK(t *testing.T) {
+	client := &http.Client{Transport: &httperrors.Transport{
+		Base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusNoContent)
+			return rec.Result(), nil
+		}),
+	}}
+
+	res, err := client.Get("http://service/health")
+	if err != nil {
+		t.Fatalf("expect no error, but got %v", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		t.Errorf("expect status 204, but got %d", res.StatusCode)
+	}
+}
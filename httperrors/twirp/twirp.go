@@ -0,0 +1,211 @@
+// Package twirp maps the failure types of this module onto the Twirp wire
+// format ({"code":...,"msg":...,"meta":{...}}) and back, so services
+// speaking Twirp get consistent typed errors without custom glue.
+//
+// Violations travel in the meta map under prefixed keys ("field:email",
+// "conflict:bookings/42", ...), since Twirp metadata is flat string pairs.
+package twirp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deixis/errors"
+)
+
+// twirpError is the Twirp JSON error envelope
+type twirpError struct {
+	Code string            `json:"code"`
+	Msg  string            `json:"msg"`
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// Twirp error codes and the HTTP statuses mandated by the spec
+var statusCodes = map[string]int{
+	"canceled":            408,
+	"unknown":             500,
+	"invalid_argument":    400,
+	"malformed":           400,
+	"deadline_exceeded":   408,
+	"not_found":           404,
+	"bad_route":           404,
+	"already_exists":      409,
+	"permission_denied":   403,
+	"unauthenticated":     401,
+	"resource_exhausted":  429,
+	"failed_precondition": 412,
+	"aborted":             409,
+	"out_of_range":        400,
+	"unimplemented":       501,
+	"internal":            500,
+	"unavailable":         503,
+	"data_loss":           500,
+}
+
+// codeNames maps canonical codes to Twirp code strings
+var codeNames = map[errors.Code]string{
+	errors.CodeCancelled:          "canceled",
+	errors.CodeUnknown:            "unknown",
+	errors.CodeInvalidArgument:    "invalid_argument",
+	errors.CodeDeadlineExceeded:   "deadline_exceeded",
+	errors.CodeNotFound:           "not_found",
+	errors.CodeAlreadyExists:      "already_exists",
+	errors.CodePermissionDenied:   "permission_denied",
+	errors.CodeUnauthenticated:    "unauthenticated",
+	errors.CodeResourceExhausted:  "resource_exhausted",
+	errors.CodeFailedPrecondition: "failed_precondition",
+	errors.CodeAborted:            "aborted",
+	errors.CodeOutOfRange:         "out_of_range",
+	errors.CodeUnimplemented:      "unimplemented",
+	errors.CodeInternal:           "internal",
+	errors.CodeUnavailable:        "unavailable",
+	errors.CodeDataLoss:           "data_loss",
+}
+
+// Marshal writes `err` to the response writer in the Twirp error format
+func Marshal(w http.ResponseWriter, err error) error {
+	te := pack(err)
+	w.Header().Set("Content-Type", "application/json")
+	status, ok := statusCodes[te.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(te)
+}
+
+// Unmarshal extracts the error carried by a Twirp error response
+func Unmarshal(res *http.Response) error {
+	if res.StatusCode < 400 {
+		return nil
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 64<<10)) // Ignore errors
+
+	te := &twirpError{}
+	if err := json.Unmarshal(body, te); err != nil || te.Code == "" {
+		return errors.Errorf("twirp error: status = %d", res.StatusCode)
+	}
+	return unpack(te)
+}
+
+// pack builds the Twirp envelope for err
+func pack(err error) *twirpError {
+	name, ok := codeNames[errors.CodeOf(err)]
+	if !ok {
+		name = "unknown"
+	}
+	te := &twirpError{
+		Code: name,
+		Msg:  err.Error(),
+		Meta: map[string]string{},
+	}
+	if msg := errors.UserMessage(err); msg != "" {
+		te.Msg = msg
+	}
+	if d := errors.RetryAfter(err); d > 0 {
+		te.Meta["retry_after_seconds"] = strconv.Itoa(int(d.Seconds()))
+	}
+
+	if f, ok := errors.AsBadRequest(err); ok {
+		for _, v := range f.Violations {
+			te.Meta["field:"+v.Field] = v.Description
+		}
+	}
+	if f, ok := errors.AsPreconditionFailure(err); ok {
+		for _, v := range f.Violations {
+			te.Meta[fmt.Sprintf("precondition:%s:%s", v.Type, v.Subject)] = v.Description
+		}
+	}
+	if f, ok := errors.AsConflictFailure(err); ok {
+		for _, v := range f.Violations {
+			te.Meta["conflict:"+v.Resource] = v.Description
+		}
+	}
+	if f, ok := errors.AsQuotaFailure(err); ok {
+		for _, v := range f.Violations {
+			te.Meta["quota:"+v.Subject] = v.Description
+		}
+	}
+	if len(te.Meta) == 0 {
+		te.Meta = nil
+	}
+	return te
+}
+
+// unpack reconstructs the error described by the Twirp envelope
+func unpack(te *twirpError) error {
+	var opts []errors.Option
+	for k, v := range te.Meta {
+		switch {
+		case strings.HasPrefix(k, "field:"):
+			opts = append(opts, &errors.FieldViolation{
+				Field:       strings.TrimPrefix(k, "field:"),
+				Description: v,
+			})
+		case strings.HasPrefix(k, "precondition:"):
+			parts := strings.SplitN(strings.TrimPrefix(k, "precondition:"), ":", 2)
+			violation := &errors.PreconditionViolation{Description: v}
+			violation.Type = parts[0]
+			if len(parts) == 2 {
+				violation.Subject = parts[1]
+			}
+			opts = append(opts, violation)
+		case strings.HasPrefix(k, "conflict:"):
+			opts = append(opts, &errors.ConflictViolation{
+				Resource:    strings.TrimPrefix(k, "conflict:"),
+				Description: v,
+			})
+		case strings.HasPrefix(k, "quota:"):
+			opts = append(opts, &errors.QuotaViolation{
+				Subject:     strings.TrimPrefix(k, "quota:"),
+				Description: v,
+			})
+		}
+	}
+	var retryDelay time.Duration
+	if s, ok := te.Meta["retry_after_seconds"]; ok {
+		if seconds, err := strconv.Atoi(s); err == nil && seconds > 0 {
+			retryDelay = time.Duration(seconds) * time.Second
+		}
+	}
+
+	switch te.Code {
+	case "canceled":
+		return errors.WithCanceled(errors.New(te.Msg))
+	case "deadline_exceeded":
+		return errors.WithDeadlineExceeded(errors.New(te.Msg))
+	case "invalid_argument", "malformed":
+		return errors.Bad(opts...)
+	case "not_found", "bad_route":
+		return errors.NotFound
+	case "already_exists", "aborted":
+		return errors.Aborted(opts...)
+	case "permission_denied":
+		return errors.PermissionDenied
+	case "unauthenticated":
+		return errors.Unauthenticated
+	case "resource_exhausted":
+		if retryDelay > 0 {
+			opts = append(opts, errors.WithRetryInfo(retryDelay))
+		}
+		return errors.ResourceExhausted(opts...)
+	case "failed_precondition":
+		return errors.FailedPrecondition(opts...)
+	case "out_of_range":
+		return errors.OutOfRange(opts...)
+	case "unavailable":
+		return errors.Unavailable(retryDelay)
+	case "data_loss":
+		return errors.WithDataLoss(errors.New(te.Msg))
+	case "internal", "unimplemented", "unknown":
+		return errors.New(te.Msg)
+	}
+	return errors.New(te.Msg)
+}
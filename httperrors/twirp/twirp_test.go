@@ -0,0 +1,85 @@
+package twirp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/httperrors/twirp"
+)
+
+func TestTwirp_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name: "bad request",
+			err: errors.Bad(&errors.FieldViolation{
+				Field:       "email",
+				Description: "Invalid email address",
+			}),
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "conflict",
+			err: errors.Aborted(&errors.ConflictViolation{
+				Resource:    "bookings/42",
+				Description: "Booking was modified concurrently",
+			}),
+			status: http.StatusConflict,
+		},
+		{
+			name: "quota",
+			err: errors.ResourceExhausted(&errors.QuotaViolation{
+				Subject:     "project:x/read",
+				Description: "Daily limit exceeded",
+			}),
+			status: http.StatusTooManyRequests,
+		},
+		{name: "not found", err: errors.NotFound, status: http.StatusNotFound},
+		{name: "unauthenticated", err: errors.Unauthenticated, status: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if err := twirp.Marshal(rec, test.err); err != nil {
+				t.Fatalf("expect to marshal error, but got %v", err)
+			}
+			if rec.Code != test.status {
+				t.Errorf("expect status %d, but got %d", test.status, rec.Code)
+			}
+
+			got := twirp.Unmarshal(rec.Result())
+			if !errors.Match(got, test.err) {
+				t.Errorf("expect %v, but got %v", test.err, got)
+			}
+		})
+	}
+}
+
+func TestTwirp_CodeNames(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := twirp.Marshal(rec, errors.Unavailable(30*time.Second)); err != nil {
+		t.Fatalf("expect to marshal error, but got %v", err)
+	}
+
+	var te struct {
+		Code string            `json:"code"`
+		Meta map[string]string `json:"meta"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &te); err != nil {
+		t.Fatalf("expect a JSON body, but got %v", err)
+	}
+	if te.Code != "unavailable" {
+		t.Errorf("expect code unavailable, but got %s", te.Code)
+	}
+	if te.Meta["retry_after_seconds"] != "30" {
+		t.Errorf("expect a retry hint, but got %v", te.Meta)
+	}
+}
@@ -0,0 +1,41 @@
+package httperrors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/deixis/errors"
+)
+
+// WarningHeader is the header under which warnings travel, one JSON-encoded
+// warning per header value
+const WarningHeader = "X-Errors-Warning"
+
+// MarshalWarnings writes the warnings collected on ctx (see
+// errors.CollectWarnings) as response headers, so clients receive
+// partial-degradation conditions alongside a successful response. It must
+// be called before the response body is written. It is a no-op when no
+// warning was raised.
+func MarshalWarnings(ctx context.Context, w http.ResponseWriter) error {
+	for _, warning := range errors.Warnings(ctx) {
+		data, err := json.Marshal(warning)
+		if err != nil {
+			return err
+		}
+		w.Header().Add(WarningHeader, string(data))
+	}
+	return nil
+}
+
+// UnmarshalWarnings extracts the warnings carried by response headers
+func UnmarshalWarnings(res *http.Response) []*errors.Warning {
+	var warnings []*errors.Warning
+	for _, v := range res.Header.Values(WarningHeader) {
+		warning := &errors.Warning{}
+		if err := json.Unmarshal([]byte(v), warning); err == nil {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
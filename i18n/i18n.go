@@ -0,0 +1,99 @@
+// Package i18n resolves localised error messages from message catalogs
+// registered by services. Messages are keyed by domain and reason, so the
+// same failure can be translated consistently across service boundaries
+// without every service hand-rolling its own lookup.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/deixis/errors"
+	"golang.org/x/text/language"
+)
+
+// Key identifies a message in the catalog. Services attach it to their
+// errors (see Annotate), so the key travels with the error and can be
+// resolved wherever the error surfaces.
+type Key struct {
+	// Domain namespaces the reason, e.g. "billing" or "auth".
+	Domain string
+	// Reason identifies the failure within the domain, e.g. "CARD_EXPIRED".
+	Reason string
+}
+
+var catalog = struct {
+	mu       sync.RWMutex
+	messages map[Key]errors.LocalisedString
+}{
+	messages: map[Key]errors.LocalisedString{},
+}
+
+// Register declares the translations of a message. It is expected to be
+// called at init time and panics when the key has already been registered.
+func Register(domain, reason string, messages errors.LocalisedString) {
+	catalog.mu.Lock()
+	defer catalog.mu.Unlock()
+
+	key := Key{Domain: domain, Reason: reason}
+	if _, ok := catalog.messages[key]; ok {
+		panic(fmt.Sprintf("i18n: message %s/%s registered twice", domain, reason))
+	}
+	catalog.messages[key] = messages
+}
+
+// Lookup returns the registered translations for the given key.
+func Lookup(domain, reason string) (errors.LocalisedString, bool) {
+	catalog.mu.RLock()
+	defer catalog.mu.RUnlock()
+
+	messages, ok := catalog.messages[Key{Domain: domain, Reason: reason}]
+	return messages, ok
+}
+
+// Annotate attaches the catalog key to `err`, so Localize can later resolve
+// the message registered under it.
+// If err is nil, Annotate returns nil.
+func Annotate(err error, domain, reason string) error {
+	return errors.WithDetails(err, &Key{Domain: domain, Reason: reason})
+}
+
+// Localize resolves the best translation of `err` for the locales carried
+// by ctx. It first looks up the catalog key attached to the error, then
+// falls back to the localised messages attached directly to the error, and
+// finally returns an empty string when no translation is available.
+func Localize(ctx context.Context, err error) string {
+	preferred := errors.LocaleFromContext(ctx)
+	locales := make([]string, len(preferred))
+	for i, t := range preferred {
+		locales[i] = t.String()
+	}
+
+	for _, d := range errors.Details(err) {
+		key, ok := d.(*Key)
+		if !ok {
+			continue
+		}
+		messages, ok := Lookup(key.Domain, key.Reason)
+		if !ok {
+			continue
+		}
+		if s, err := messages.Match(locales...); err == nil && s != "" {
+			return s
+		}
+	}
+	return errors.LocalizedMessage(err, preferred...)
+}
+
+// WithLocale returns a context carrying the user's preferred locales, in
+// order of preference. It is a convenience alias for errors.WithLocale.
+func WithLocale(ctx context.Context, preferred ...language.Tag) context.Context {
+	return errors.WithLocale(ctx, preferred...)
+}
+
+// Locale returns the preferred locales carried by ctx. It is a convenience
+// alias for errors.LocaleFromContext.
+func Locale(ctx context.Context) []language.Tag {
+	return errors.LocaleFromContext(ctx)
+}
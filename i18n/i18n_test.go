@@ -0,0 +1,75 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/i18n"
+	"golang.org/x/text/language"
+)
+
+func TestLocalize(t *testing.T) {
+	i18n.Register("billing", "CARD_EXPIRED", errors.LocalisedString{
+		"en": "Your card has expired",
+		"fr": "Votre carte a expiré",
+	})
+
+	err := i18n.Annotate(errors.FailedPrecondition(), "billing", "CARD_EXPIRED")
+	ctx := i18n.WithLocale(context.Background(), language.French)
+
+	if got := i18n.Localize(ctx, err); got != "Votre carte a expiré" {
+		t.Errorf("expect the French message, but got %q", got)
+	}
+	if got := i18n.Localize(context.Background(), err); got != "Your card has expired" {
+		t.Errorf("expect the English message by default, but got %q", got)
+	}
+}
+
+func TestLocalize_Fallback(t *testing.T) {
+	// Without a catalog key, Localize falls back to the messages attached
+	// directly to the error
+	err := errors.WithLocalizedMessage(
+		errors.New("boom"), language.English, "Something went wrong",
+	)
+	if got := i18n.Localize(context.Background(), err); got != "Something went wrong" {
+		t.Errorf("expect the attached message, but got %q", got)
+	}
+
+	if got := i18n.Localize(context.Background(), errors.New("boom")); got != "" {
+		t.Errorf("expect an empty string without a translation, but got %q", got)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	i18n.Register("auth", "SESSION_EXPIRED", errors.LocalisedString{
+		"en": "Your session has expired",
+	})
+
+	messages, ok := i18n.Lookup("auth", "SESSION_EXPIRED")
+	if !ok {
+		t.Fatalf("expect the registered messages, but got none")
+	}
+	if messages["en"] != "Your session has expired" {
+		t.Errorf("expect the registered message, but got %q", messages["en"])
+	}
+
+	if _, ok := i18n.Lookup("auth", "UNKNOWN"); ok {
+		t.Errorf("expect no messages for an unknown key")
+	}
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	i18n.Register("auth", "LOCKED_OUT", errors.LocalisedString{
+		"en": "Your account is locked",
+	})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expect Register to panic on a duplicate key")
+		}
+	}()
+	i18n.Register("auth", "LOCKED_OUT", errors.LocalisedString{
+		"en": "Your account is locked",
+	})
+}
@@ -0,0 +1,51 @@
+package errors
+
+import "strings"
+
+// Join aggregates multiple failures into a single error. Nil errors are
+// discarded and nested joins are flattened. Join returns nil when every
+// given error is nil, and the error itself when only one remains.
+//
+// Transports serialise the violations and details of every member, but can
+// only carry the code of the first one.
+func Join(errs ...error) error {
+	flat := make([]error, 0, len(errs))
+	for _, err := range errs {
+		switch err := err.(type) {
+		case nil:
+		case *MultiFailure:
+			flat = append(flat, err.Errors...)
+		default:
+			flat = append(flat, err)
+		}
+	}
+
+	switch len(flat) {
+	case 0:
+		return nil
+	case 1:
+		return flat[0]
+	}
+	return &MultiFailure{Errors: flat}
+}
+
+// MultiFailure aggregates multiple independent failures into one error,
+// e.g. the failures of a batch operation.
+type MultiFailure struct {
+	// The aggregated failures.
+	Errors []error
+}
+
+func (e *MultiFailure) Error() string {
+	s := make([]string, len(e.Errors))
+	for i := range e.Errors {
+		s[i] = e.Errors[i].Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Unwrap returns the aggregated failures, so `Is` and `As` match any of
+// the members.
+func (e *MultiFailure) Unwrap() []error {
+	return e.Errors
+}
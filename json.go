@@ -0,0 +1,393 @@
+package errors
+
+import (
+	"encoding/json"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// jsonEnvelope is the self-describing JSON representation of an error. The
+// type discriminator tells UnmarshalJSON which failure to reconstruct, so
+// errors can be persisted (e.g. to queues or document stores) and
+// reconstructed later without going through the HTTP or gRPC transports.
+type jsonEnvelope struct {
+	Type       string          `json:"type"`
+	Message    string          `json:"message,omitempty"`
+	OccurredAt *time.Time      `json:"occurredAt,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	Resource   string          `json:"resource,omitempty"`
+	RetryInfo  *RetryInfo      `json:"retryInfo,omitempty"`
+	DebugInfo  *DebugInfo      `json:"debugInfo,omitempty"`
+	Violations *jsonViolations `json:"violations,omitempty"`
+	Cause      *jsonEnvelope   `json:"cause,omitempty"`
+	Errors     []*jsonEnvelope `json:"errors,omitempty"`
+	Details    []*jsonDetail   `json:"details,omitempty"`
+}
+
+// jsonViolations groups the violations of a failure by kind
+type jsonViolations struct {
+	Fields        []*FieldViolation        `json:"fields,omitempty"`
+	Preconditions []*PreconditionViolation `json:"preconditions,omitempty"`
+	Conflicts     []*ConflictViolation     `json:"conflicts,omitempty"`
+	Quotas        []*QuotaViolation        `json:"quotas,omitempty"`
+	Ranges        []*RangeViolation        `json:"ranges,omitempty"`
+}
+
+// jsonDetail wraps a detail attached with WithDetails with a type
+// discriminator. Details that are not owned by this package are preserved
+// as opaque JSON and decode into generic values.
+type jsonDetail struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// jsonLocalisedMessage mirrors LocalisedMessage with a string locale,
+// because language.Tag does not marshal to JSON
+type jsonLocalisedMessage struct {
+	Locale  string `json:"locale"`
+	Message string `json:"message"`
+}
+
+// Envelope type discriminators
+const (
+	jsonTypeOpaque       = "opaque"
+	jsonTypeDetails      = "details"
+	jsonTypeMulti        = "multi"
+	jsonTypeNotFound     = "notFound"
+	jsonTypePermission   = "permissionDenied"
+	jsonTypeAuth         = "unauthenticated"
+	jsonTypeBad          = "badRequest"
+	jsonTypePrecondition = "preconditionFailure"
+	jsonTypeConflict     = "conflict"
+	jsonTypeQuota        = "quotaFailure"
+	jsonTypeAvailability = "unavailable"
+	jsonTypeDataLoss     = "dataLoss"
+	jsonTypeInternal     = "internal"
+	jsonTypeOutOfRange   = "outOfRange"
+	jsonTypeTimeout      = "timeout"
+	jsonTypeCancelled    = "cancelled"
+	jsonTypeNotImpl      = "notImplemented"
+	jsonTypeExists       = "alreadyExists"
+)
+
+// MarshalJSON encodes `err` into a self-describing JSON envelope that
+// UnmarshalJSON can reconstruct. Failures and details owned by this package
+// survive the round trip losslessly; other errors are preserved as opaque
+// messages. A nil error encodes to JSON null.
+func MarshalJSON(err error) ([]byte, error) {
+	return json.Marshal(encodeJSON(err))
+}
+
+// UnmarshalJSON reconstructs an error encoded with MarshalJSON.
+func UnmarshalJSON(data []byte) (error, error) {
+	var env *jsonEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+	return decodeJSON(env), nil
+}
+
+func encodeJSON(err error) *jsonEnvelope {
+	if err == nil {
+		return nil
+	}
+
+	env := &jsonEnvelope{}
+	// stamp records the failure's own timestamp, not the one of the errors
+	// it wraps, so nested envelopes do not duplicate it
+	stamp := func(t time.Time) {
+		if !t.IsZero() {
+			env.OccurredAt = &t
+		}
+	}
+
+	switch err := err.(type) {
+	case *withDetails:
+		env.Type = jsonTypeDetails
+		env.Cause = encodeJSON(err.error)
+		for _, d := range err.details {
+			env.Details = append(env.Details, encodeJSONDetail(d))
+		}
+	case *MultiFailure:
+		env.Type = jsonTypeMulti
+		for _, e := range err.Errors {
+			env.Errors = append(env.Errors, encodeJSON(e))
+		}
+	case *MissingFailure:
+		env.Type = jsonTypeNotFound
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+	case *PermissionFailure:
+		env.Type = jsonTypePermission
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+	case *AuthenticationFailure:
+		env.Type = jsonTypeAuth
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+	case *BadRequest:
+		env.Type = jsonTypeBad
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		if len(err.Violations) > 0 {
+			env.Violations = &jsonViolations{Fields: err.Violations}
+		}
+	case *PreconditionFailure:
+		env.Type = jsonTypePrecondition
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		if len(err.Violations) > 0 {
+			env.Violations = &jsonViolations{Preconditions: err.Violations}
+		}
+	case *ConflictFailure:
+		env.Type = jsonTypeConflict
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		if len(err.Violations) > 0 {
+			env.Violations = &jsonViolations{Conflicts: err.Violations}
+		}
+	case *QuotaFailure:
+		env.Type = jsonTypeQuota
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		if len(err.Violations) > 0 {
+			env.Violations = &jsonViolations{Quotas: err.Violations}
+		}
+	case *OutOfRangeFailure:
+		env.Type = jsonTypeOutOfRange
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		if len(err.Violations) > 0 {
+			env.Violations = &jsonViolations{Ranges: err.Violations}
+		}
+	case *AvailabilityFailure:
+		env.Type = jsonTypeAvailability
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		info := err.RetryInfo
+		env.RetryInfo = &info
+		if err.Reason != ReasonUnspecified {
+			env.Reason = err.Reason.String()
+		}
+	case *DataLossFailure:
+		env.Type = jsonTypeDataLoss
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		env.DebugInfo = err.DebugInfo
+	case *InternalFailure:
+		env.Type = jsonTypeInternal
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		env.DebugInfo = err.DebugInfo
+	case *TimeoutFailure:
+		env.Type = jsonTypeTimeout
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+	case *CancelledFailure:
+		env.Type = jsonTypeCancelled
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+	case *NotImplementedFailure:
+		env.Type = jsonTypeNotImpl
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+	case *ExistsFailure:
+		env.Type = jsonTypeExists
+		env.Cause = encodeJSON(err.error)
+		stamp(err.OccurredAt)
+		env.Resource = err.Resource
+	default:
+		env.Type = jsonTypeOpaque
+		env.Message = err.Error()
+	}
+	return env
+}
+
+func decodeJSON(env *jsonEnvelope) error {
+	if env == nil {
+		return nil
+	}
+
+	var cause error
+	if env.Cause != nil {
+		cause = decodeJSON(env.Cause)
+	}
+	var occurredAt time.Time
+	if env.OccurredAt != nil {
+		occurredAt = *env.OccurredAt
+	}
+	var err error
+
+	switch env.Type {
+	case jsonTypeDetails:
+		details := make([]interface{}, 0, len(env.Details))
+		for _, d := range env.Details {
+			details = append(details, decodeJSONDetail(d))
+		}
+		return &withDetails{cause, details}
+	case jsonTypeMulti:
+		f := &MultiFailure{}
+		for _, e := range env.Errors {
+			f.Errors = append(f.Errors, decodeJSON(e))
+		}
+		return f
+	case jsonTypeNotFound:
+		if cause == nil && occurredAt.IsZero() {
+			return NotFound
+		}
+		err = &MissingFailure{error: cause, OccurredAt: occurredAt}
+	case jsonTypePermission:
+		if cause == nil && occurredAt.IsZero() {
+			return PermissionDenied
+		}
+		err = &PermissionFailure{error: cause, OccurredAt: occurredAt}
+	case jsonTypeAuth:
+		if cause == nil && occurredAt.IsZero() {
+			return Unauthenticated
+		}
+		err = &AuthenticationFailure{error: cause, OccurredAt: occurredAt}
+	case jsonTypeBad:
+		f := &BadRequest{error: cause, OccurredAt: occurredAt}
+		if env.Violations != nil {
+			f.Violations = env.Violations.Fields
+		}
+		err = f
+	case jsonTypePrecondition:
+		f := &PreconditionFailure{error: cause, OccurredAt: occurredAt}
+		if env.Violations != nil {
+			f.Violations = env.Violations.Preconditions
+		}
+		err = f
+	case jsonTypeConflict:
+		f := &ConflictFailure{error: cause, OccurredAt: occurredAt}
+		if env.Violations != nil {
+			f.Violations = env.Violations.Conflicts
+		}
+		err = f
+	case jsonTypeQuota:
+		f := &QuotaFailure{error: cause, OccurredAt: occurredAt}
+		if env.Violations != nil {
+			f.Violations = env.Violations.Quotas
+		}
+		err = f
+	case jsonTypeOutOfRange:
+		f := &OutOfRangeFailure{error: cause, OccurredAt: occurredAt}
+		if env.Violations != nil {
+			f.Violations = env.Violations.Ranges
+		}
+		err = f
+	case jsonTypeAvailability:
+		f := &AvailabilityFailure{
+			error:      cause,
+			OccurredAt: occurredAt,
+			Reason:     ParseAvailabilityReason(env.Reason),
+		}
+		if env.RetryInfo != nil {
+			f.RetryInfo = *env.RetryInfo
+		}
+		err = f
+	case jsonTypeDataLoss:
+		if cause == nil && occurredAt.IsZero() && env.DebugInfo == nil {
+			return DataLoss
+		}
+		err = &DataLossFailure{error: cause, OccurredAt: occurredAt, DebugInfo: env.DebugInfo}
+	case jsonTypeInternal:
+		err = &InternalFailure{error: cause, OccurredAt: occurredAt, DebugInfo: env.DebugInfo}
+	case jsonTypeTimeout:
+		err = &TimeoutFailure{error: cause, OccurredAt: occurredAt}
+	case jsonTypeCancelled:
+		err = &CancelledFailure{error: cause, OccurredAt: occurredAt}
+	case jsonTypeNotImpl:
+		err = &NotImplementedFailure{error: cause, OccurredAt: occurredAt}
+	case jsonTypeExists:
+		err = &ExistsFailure{error: cause, OccurredAt: occurredAt, Resource: env.Resource}
+	default:
+		return New(env.Message)
+	}
+	return err
+}
+
+// Detail type discriminators
+const (
+	jsonDetailRetryInfo        = "retryInfo"
+	jsonDetailDebugInfo        = "debugInfo"
+	jsonDetailLocalisedMessage = "localisedMessage"
+	jsonDetailHelp             = "help"
+	jsonDetailCorrelation      = "correlation"
+	jsonDetailMetadata         = "metadata"
+	jsonDetailUserMessage      = "userMessage"
+	jsonDetailOpaque           = "opaque"
+)
+
+func encodeJSONDetail(detail interface{}) *jsonDetail {
+	d := &jsonDetail{Type: jsonDetailOpaque}
+	switch detail := detail.(type) {
+	case *RetryInfo:
+		d.Type = jsonDetailRetryInfo
+	case *DebugInfo:
+		d.Type = jsonDetailDebugInfo
+	case *LocalisedMessage:
+		d.Type = jsonDetailLocalisedMessage
+		d.Value, _ = json.Marshal(&jsonLocalisedMessage{
+			Locale:  detail.Locale.String(),
+			Message: detail.Message,
+		})
+		return d
+	case *Help:
+		d.Type = jsonDetailHelp
+	case *Correlation:
+		d.Type = jsonDetailCorrelation
+	case Metadata:
+		d.Type = jsonDetailMetadata
+	case *userMessage:
+		d.Type = jsonDetailUserMessage
+	}
+	d.Value, _ = json.Marshal(detail)
+	return d
+}
+
+func decodeJSONDetail(d *jsonDetail) interface{} {
+	switch d.Type {
+	case jsonDetailRetryInfo:
+		v := &RetryInfo{}
+		if err := json.Unmarshal(d.Value, v); err == nil {
+			return v
+		}
+	case jsonDetailDebugInfo:
+		v := &DebugInfo{}
+		if err := json.Unmarshal(d.Value, v); err == nil {
+			return v
+		}
+	case jsonDetailLocalisedMessage:
+		v := &jsonLocalisedMessage{}
+		if err := json.Unmarshal(d.Value, v); err == nil {
+			if tag, err := language.Parse(v.Locale); err == nil {
+				return &LocalisedMessage{Locale: tag, Message: v.Message}
+			}
+		}
+	case jsonDetailHelp:
+		v := &Help{}
+		if err := json.Unmarshal(d.Value, v); err == nil {
+			return v
+		}
+	case jsonDetailCorrelation:
+		v := &Correlation{}
+		if err := json.Unmarshal(d.Value, v); err == nil {
+			return v
+		}
+	case jsonDetailMetadata:
+		v := Metadata{}
+		if err := json.Unmarshal(d.Value, &v); err == nil {
+			return v
+		}
+	case jsonDetailUserMessage:
+		v := &userMessage{}
+		if err := json.Unmarshal(d.Value, v); err == nil {
+			return v
+		}
+	}
+	var v interface{}
+	json.Unmarshal(d.Value, &v) // Best effort
+	return v
+}
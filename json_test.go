@@ -0,0 +1,125 @@
+package errors_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"golang.org/x/text/language"
+)
+
+func TestJSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "bad request with violations",
+			err: errors.Bad(&errors.FieldViolation{
+				Field:       "email",
+				Description: "invalid email address",
+				Reason:      "EMAIL",
+			}),
+		},
+		{
+			name: "quota failure",
+			err: errors.ResourceExhausted(&errors.QuotaViolation{
+				Subject:     "api-calls",
+				Description: "rate limit exceeded",
+				Limit:       100,
+				Current:     101,
+			}),
+		},
+		{
+			name: "unavailable with reason",
+			err:  errors.UnavailableBecause(errors.ReasonOverload, 30*time.Second),
+		},
+		{
+			name: "wrapped with details",
+			err: errors.WithDetails(
+				errors.WithNotFound(errors.New("no such user")),
+				&errors.Correlation{ID: "transit-123"},
+				errors.Metadata{"tenant": "acme"},
+			),
+		},
+		{
+			name: "localised message detail",
+			err: errors.WithLocalizedMessage(
+				errors.Aborted(), language.French, "opération annulée",
+			),
+		},
+		{
+			name: "multi failure",
+			err: errors.Join(
+				errors.Bad(&errors.FieldViolation{Field: "a", Description: "missing"}),
+				errors.FailedPrecondition(),
+			),
+		},
+		{
+			name: "already exists",
+			err:  errors.AlreadyExists("user:42"),
+		},
+		{
+			name: "opaque error",
+			err:  errors.New("something went wrong"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := errors.MarshalJSON(test.err)
+			if err != nil {
+				t.Fatalf("expect MarshalJSON to succeed, but got error %v", err)
+			}
+			got, err := errors.UnmarshalJSON(data)
+			if err != nil {
+				t.Fatalf("expect UnmarshalJSON to succeed, but got error %v", err)
+			}
+			if !errors.Match(got, test.err) {
+				t.Errorf("expect errors to match after round trip, but got %v", got)
+			}
+			if got.Error() != test.err.Error() {
+				t.Errorf("expect message %q, but got %q", test.err.Error(), got.Error())
+			}
+		})
+	}
+}
+
+func TestJSON_Sentinels(t *testing.T) {
+	sentinels := []error{
+		errors.NotFound,
+		errors.PermissionDenied,
+		errors.Unauthenticated,
+		errors.DataLoss,
+	}
+	for _, sentinel := range sentinels {
+		data, err := errors.MarshalJSON(sentinel)
+		if err != nil {
+			t.Fatalf("expect MarshalJSON to succeed, but got error %v", err)
+		}
+		got, err := errors.UnmarshalJSON(data)
+		if err != nil {
+			t.Fatalf("expect UnmarshalJSON to succeed, but got error %v", err)
+		}
+		if got != sentinel {
+			t.Errorf("expect sentinel %v to survive the round trip, but got %v", sentinel, got)
+		}
+	}
+}
+
+func TestJSON_Nil(t *testing.T) {
+	data, err := errors.MarshalJSON(nil)
+	if err != nil {
+		t.Fatalf("expect MarshalJSON to succeed, but got error %v", err)
+	}
+	if string(data) != "null" {
+		t.Errorf("expect null, but got %s", data)
+	}
+	got, err := errors.UnmarshalJSON(data)
+	if err != nil {
+		t.Fatalf("expect UnmarshalJSON to succeed, but got error %v", err)
+	}
+	if got != nil {
+		t.Errorf("expect nil error, but got %v", got)
+	}
+}
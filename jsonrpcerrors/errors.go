@@ -0,0 +1,233 @@
+// Package jsonrpcerrors maps typed failures onto JSON-RPC 2.0 error
+// objects and back, for tooling that still speaks JSON-RPC. Violations
+// travel in the error data, next to the canonical envelope that makes the
+// roundtrip lossless between Go peers.
+package jsonrpcerrors
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/deixis/errors"
+)
+
+// JSON-RPC 2.0 error codes. Failures without a pre-defined code map into
+// the implementation-defined server error range (-32000 to -32099).
+const (
+	CodeInvalidParams  = -32602
+	CodeMethodNotFound = -32601
+	CodeInternal       = -32603
+
+	CodeUnauthenticated    = -32001
+	CodePermissionDenied   = -32003
+	CodeNotFound           = -32004
+	CodeAborted            = -32009
+	CodeFailedPrecondition = -32012
+	CodeResourceExhausted  = -32029
+	CodeUnavailable        = -32050
+	CodeDeadlineExceeded   = -32060
+	CodeCancelled          = -32061
+	CodeDataLoss           = -32062
+)
+
+// Error is the JSON-RPC 2.0 error object
+type Error struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    *ErrorData `json:"data,omitempty"`
+}
+
+// ErrorData carries the structured failure data of an error object
+type ErrorData struct {
+	// Violations is the flattened form of the violations attached to the
+	// error
+	Violations []*Violation `json:"violations,omitempty"`
+	// RetryAfterSeconds tells the client when it can retry
+	RetryAfterSeconds int `json:"retryAfterSeconds,omitempty"`
+	// Raw is the canonical envelope (see errors.MarshalJSON), which makes
+	// the roundtrip lossless between Go peers
+	Raw json.RawMessage `json:"raw,omitempty"`
+}
+
+// Violation is the flattened form of the violation types of this module
+type Violation struct {
+	Field       string `json:"field,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Subject     string `json:"subject,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// From builds the JSON-RPC error object for err. It returns nil when err
+// is nil.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	if um := errors.UserMessage(err); um != "" {
+		msg = um
+	}
+	e := &Error{
+		Code:    rpcCode(errors.CodeOf(err)),
+		Message: msg,
+		Data:    &ErrorData{},
+	}
+	if d := errors.RetryAfter(err); d > 0 {
+		e.Data.RetryAfterSeconds = int(d.Seconds())
+	}
+	e.Data.Violations = packViolations(err)
+	e.Data.Raw, _ = errors.MarshalJSON(err)
+	return e
+}
+
+// ToError reconstructs the error described by a JSON-RPC error object. It
+// returns nil when e is nil.
+func ToError(e *Error) error {
+	if e == nil {
+		return nil
+	}
+	if e.Data != nil && len(e.Data.Raw) > 0 {
+		if err, decodeErr := errors.UnmarshalJSON(e.Data.Raw); decodeErr == nil {
+			return err
+		}
+	}
+
+	var opts []errors.Option
+	var retryDelay time.Duration
+	if e.Data != nil {
+		opts = unpackViolations(e.Code, e.Data.Violations)
+		retryDelay = time.Duration(e.Data.RetryAfterSeconds) * time.Second
+	}
+
+	switch e.Code {
+	case CodeInvalidParams:
+		return errors.Bad(opts...)
+	case CodeNotFound, CodeMethodNotFound:
+		return errors.NotFound
+	case CodePermissionDenied:
+		return errors.PermissionDenied
+	case CodeUnauthenticated:
+		return errors.Unauthenticated
+	case CodeAborted:
+		return errors.Aborted(opts...)
+	case CodeFailedPrecondition:
+		return errors.FailedPrecondition(opts...)
+	case CodeResourceExhausted:
+		if retryDelay > 0 {
+			opts = append(opts, errors.WithRetryInfo(retryDelay))
+		}
+		return errors.ResourceExhausted(opts...)
+	case CodeUnavailable:
+		return errors.Unavailable(retryDelay)
+	case CodeDeadlineExceeded:
+		return errors.WithDeadlineExceeded(errors.New(e.Message))
+	case CodeCancelled:
+		return errors.WithCanceled(errors.New(e.Message))
+	case CodeDataLoss:
+		return errors.WithDataLoss(errors.New(e.Message))
+	}
+	return errors.New(e.Message)
+}
+
+// rpcCode maps a canonical code to its JSON-RPC error code
+func rpcCode(code errors.Code) int {
+	switch code {
+	case errors.CodeInvalidArgument, errors.CodeOutOfRange:
+		return CodeInvalidParams
+	case errors.CodeUnimplemented:
+		return CodeMethodNotFound
+	case errors.CodeNotFound:
+		return CodeNotFound
+	case errors.CodePermissionDenied:
+		return CodePermissionDenied
+	case errors.CodeUnauthenticated:
+		return CodeUnauthenticated
+	case errors.CodeAborted, errors.CodeAlreadyExists:
+		return CodeAborted
+	case errors.CodeFailedPrecondition:
+		return CodeFailedPrecondition
+	case errors.CodeResourceExhausted:
+		return CodeResourceExhausted
+	case errors.CodeUnavailable:
+		return CodeUnavailable
+	case errors.CodeDeadlineExceeded:
+		return CodeDeadlineExceeded
+	case errors.CodeCancelled:
+		return CodeCancelled
+	case errors.CodeDataLoss:
+		return CodeDataLoss
+	}
+	return CodeInternal
+}
+
+// packViolations flattens the violations attached to err
+func packViolations(err error) []*Violation {
+	var violations []*Violation
+	if f, ok := errors.AsBadRequest(err); ok {
+		for _, v := range f.Violations {
+			violations = append(violations, &Violation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+	}
+	if f, ok := errors.AsPreconditionFailure(err); ok {
+		for _, v := range f.Violations {
+			violations = append(violations, &Violation{
+				Type:        v.Type,
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+	}
+	if f, ok := errors.AsConflictFailure(err); ok {
+		for _, v := range f.Violations {
+			violations = append(violations, &Violation{
+				Resource:    v.Resource,
+				Description: v.Description,
+			})
+		}
+	}
+	if f, ok := errors.AsQuotaFailure(err); ok {
+		for _, v := range f.Violations {
+			violations = append(violations, &Violation{
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+	}
+	return violations
+}
+
+// unpackViolations rebuilds the violation options matching the error code
+func unpackViolations(code int, violations []*Violation) []errors.Option {
+	opts := make([]errors.Option, 0, len(violations))
+	for _, v := range violations {
+		switch code {
+		case CodeInvalidParams:
+			opts = append(opts, &errors.FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		case CodeFailedPrecondition:
+			opts = append(opts, &errors.PreconditionViolation{
+				Type:        v.Type,
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		case CodeAborted:
+			opts = append(opts, &errors.ConflictViolation{
+				Resource:    v.Resource,
+				Description: v.Description,
+			})
+		case CodeResourceExhausted:
+			opts = append(opts, &errors.QuotaViolation{
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+	}
+	return opts
+}
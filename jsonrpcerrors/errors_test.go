@@ -0,0 +1,96 @@
+package jsonrpcerrors_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/jsonrpcerrors"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{
+			name: "bad request",
+			err: errors.Bad(&errors.FieldViolation{
+				Field:       "email",
+				Description: "Invalid email address",
+			}),
+			code: jsonrpcerrors.CodeInvalidParams,
+		},
+		{
+			name: "conflict",
+			err: errors.Aborted(&errors.ConflictViolation{
+				Resource:    "bookings/42",
+				Description: "Booking was modified concurrently",
+			}),
+			code: jsonrpcerrors.CodeAborted,
+		},
+		{name: "not found", err: errors.NotFound, code: jsonrpcerrors.CodeNotFound},
+		{
+			name: "unavailable",
+			err:  errors.Unavailable(30 * time.Second),
+			code: jsonrpcerrors.CodeUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := jsonrpcerrors.From(test.err)
+			if e.Code != test.code {
+				t.Errorf("expect code %d, but got %d", test.code, e.Code)
+			}
+
+			// The object survives its JSON encoding
+			data, err := json.Marshal(e)
+			if err != nil {
+				t.Fatalf("expect to marshal error object, but got %v", err)
+			}
+			decoded := &jsonrpcerrors.Error{}
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("expect to unmarshal error object, but got %v", err)
+			}
+
+			got := jsonrpcerrors.ToError(decoded)
+			if !errors.Match(got, test.err) {
+				t.Errorf("expect %v, but got %v", test.err, got)
+			}
+		})
+	}
+}
+
+func TestToError_Foreign(t *testing.T) {
+	// An error object produced by a non-Go peer carries no canonical
+	// envelope
+	got := jsonrpcerrors.ToError(&jsonrpcerrors.Error{
+		Code:    jsonrpcerrors.CodeInvalidParams,
+		Message: "invalid params",
+		Data: &jsonrpcerrors.ErrorData{
+			Violations: []*jsonrpcerrors.Violation{
+				{Field: "email", Description: "Invalid email address"},
+			},
+		},
+	})
+
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	if f.Violations[0].Field != "email" {
+		t.Errorf("expect the field violation, but got %v", f.Violations)
+	}
+}
+
+func TestNil(t *testing.T) {
+	if e := jsonrpcerrors.From(nil); e != nil {
+		t.Errorf("expect no error object, but got %v", e)
+	}
+	if err := jsonrpcerrors.ToError(nil); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package errors
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	lcontext "github.com/deixis/spine/context"
+	"golang.org/x/text/language"
+)
+
+// acceptLanguageKey is the shipment key under which spine transports carry
+// the Accept-Language header across process boundaries
+const acceptLanguageKey = "accept-language"
+
+type localeContextKey struct{}
+
+// WithLocale returns a context carrying the user's preferred locales, in
+// order of preference.
+func WithLocale(ctx context.Context, preferred ...language.Tag) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, preferred)
+}
+
+// LocaleFromContext returns the preferred locales carried by ctx, in order
+// of preference. When no locale was set explicitly with WithLocale, it
+// falls back to the Accept-Language shipment, and returns nil when the
+// context carries neither.
+func LocaleFromContext(ctx context.Context) []language.Tag {
+	if tags, ok := ctx.Value(localeContextKey{}).([]language.Tag); ok {
+		return tags
+	}
+	if v, ok := lcontext.Shipment(ctx, acceptLanguageKey).(string); ok {
+		if tags, _, err := language.ParseAcceptLanguage(v); err == nil {
+			return tags
+		}
+	}
+	return nil
+}
+
+// Match finds the best supported language based on the preferred list and
+// the languages for which there exists translations. Preferred locales that
+// cannot be parsed are ignored. It returns an error when one of the
+// translation keys is not a valid BCP 47 tag.
+func (s LocalisedString) Match(locales ...string) (string, error) {
+	preferred := make([]language.Tag, 0, len(locales))
+	for _, l := range locales {
+		tag, err := language.Parse(l)
+		if err != nil {
+			// Preferred locales come from client input (e.g. Accept-Language),
+			// so a bogus tag should not fail the whole lookup
+			continue
+		}
+		preferred = append(preferred, tag)
+	}
+	return s.MatchTag(preferred...)
+}
+
+// MatchTag is like Match, but accepts parsed language tags. The matcher
+// falls back through less specific tags (e.g. fr-CH matches fr), and when
+// nothing matches it falls back to the English translation, or failing that
+// to the first translation in lexicographic order, so the result is
+// deterministic.
+func (s LocalisedString) MatchTag(preferred ...language.Tag) (string, error) {
+	if len(s) == 0 {
+		return "", nil
+	}
+	m, err := s.matcher()
+	if err != nil {
+		return "", err
+	}
+
+	if len(preferred) > 0 {
+		if _, i, conf := m.matcher.Match(preferred...); conf > language.No {
+			return s[m.keys[i]], nil
+		}
+	}
+	if msg, ok := s["en"]; ok {
+		return msg, nil
+	}
+	return s[m.keys[0]], nil
+}
+
+// localeMatcher pairs a language matcher with the translation keys it was
+// built from. keys is sorted and aligned with the matcher's supported list.
+type localeMatcher struct {
+	matcher language.Matcher
+	keys    []string
+}
+
+// matcherCache caches matchers per set of translation keys, so repeated
+// lookups on the same catalog do not rebuild the matcher every time
+var matcherCache = struct {
+	mu       sync.RWMutex
+	matchers map[string]*localeMatcher
+}{
+	matchers: map[string]*localeMatcher{},
+}
+
+func (s LocalisedString) matcher() (*localeMatcher, error) {
+	keys := make([]string, 0, len(s))
+	for t := range s {
+		keys = append(keys, t)
+	}
+	sort.Strings(keys)
+	cacheKey := strings.Join(keys, "\x00")
+
+	matcherCache.mu.RLock()
+	m, ok := matcherCache.matchers[cacheKey]
+	matcherCache.mu.RUnlock()
+	if ok {
+		return m, nil
+	}
+
+	available := make([]language.Tag, len(keys))
+	for i, t := range keys {
+		tag, err := language.Parse(t)
+		if err != nil {
+			return nil, Wrapf(err, "invalid translation locale %q", t)
+		}
+		available[i] = tag
+	}
+	m = &localeMatcher{
+		matcher: language.NewMatcher(available),
+		keys:    keys,
+	}
+
+	matcherCache.mu.Lock()
+	matcherCache.matchers[cacheKey] = m
+	matcherCache.mu.Unlock()
+	return m, nil
+}
@@ -0,0 +1,92 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+	"golang.org/x/text/language"
+)
+
+func TestLocalisedString_Match(t *testing.T) {
+	s := errors.LocalisedString{
+		"en": "hello",
+		"fr": "bonjour",
+		"de": "hallo",
+	}
+
+	tests := []struct {
+		name    string
+		locales []string
+		expect  string
+	}{
+		{name: "exact match", locales: []string{"fr"}, expect: "bonjour"},
+		{name: "fallback to base language", locales: []string{"fr-CH"}, expect: "bonjour"},
+		{name: "first preference wins", locales: []string{"de", "fr"}, expect: "hallo"},
+		{name: "unparsable locale ignored", locales: []string{"!!", "de"}, expect: "hallo"},
+		{name: "no match falls back to english", locales: []string{"ja"}, expect: "hello"},
+		{name: "no preference falls back to english", locales: nil, expect: "hello"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.Match(test.locales...)
+			if err != nil {
+				t.Fatalf("expect Match to succeed, but got error %v", err)
+			}
+			if got != test.expect {
+				t.Errorf("expect message %q, but got %q", test.expect, got)
+			}
+		})
+	}
+}
+
+func TestLocalisedString_Match_InvalidKey(t *testing.T) {
+	s := errors.LocalisedString{
+		"!!": "broken",
+	}
+	if _, err := s.Match("en"); err == nil {
+		t.Error("expect Match to return an error for an invalid translation key")
+	}
+}
+
+func TestLocalisedString_MatchTag(t *testing.T) {
+	s := errors.LocalisedString{
+		"en": "hello",
+		"fr": "bonjour",
+	}
+
+	got, err := s.MatchTag(language.MustParse("fr-CH"))
+	if err != nil {
+		t.Fatalf("expect MatchTag to succeed, but got error %v", err)
+	}
+	if expect := "bonjour"; got != expect {
+		t.Errorf("expect message %q, but got %q", expect, got)
+	}
+}
+
+func TestLocalisedString_Match_Empty(t *testing.T) {
+	var s errors.LocalisedString
+	got, err := s.Match("en")
+	if err != nil {
+		t.Fatalf("expect Match to succeed, but got error %v", err)
+	}
+	if got != "" {
+		t.Errorf("expect empty message, but got %q", got)
+	}
+}
+
+func TestFieldViolation_LocalizedDescription(t *testing.T) {
+	v := &errors.FieldViolation{
+		Field:       "email",
+		Description: "Invalid email address",
+	}
+	// Without translations, the description is returned as-is
+	if got := v.LocalizedDescription(language.French); got != "Invalid email address" {
+		t.Errorf("expect the description, but got %q", got)
+	}
+
+	v.Locales = errors.LocalisedString{"fr": "Adresse e-mail invalide"}
+	if got := v.LocalizedDescription(language.French); got != "Adresse e-mail invalide" {
+		t.Errorf("expect the French description, but got %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Match reports whether `got` and `want` represent the same failure. It
+// compares the canonical code, violations (order-insensitive), retry info,
+// and attached details, but ignores wrapped causes and message formatting.
+//
+// It is primarily meant for tests, which would otherwise have to do brittle
+// string comparisons on Error().
+func Match(got, want error) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	if CodeOf(got) != CodeOf(want) {
+		return false
+	}
+	if RetryAfter(got) != RetryAfter(want) {
+		return false
+	}
+	if !matchStrings(violationSet(got), violationSet(want)) {
+		return false
+	}
+	return matchStrings(detailSet(got), detailSet(want))
+}
+
+// violationSet returns the sorted string representation of all violations
+// carried by the first typed failure in the chain of err
+func violationSet(err error) []string {
+	var s []string
+	for e := err; e != nil; e = Unwrap(e) {
+		switch e := e.(type) {
+		case *BadRequest:
+			for _, v := range e.Violations {
+				s = append(s, v.String())
+			}
+		case *PreconditionFailure:
+			for _, v := range e.Violations {
+				s = append(s, v.String())
+			}
+		case *ConflictFailure:
+			for _, v := range e.Violations {
+				s = append(s, v.String())
+			}
+		case *QuotaFailure:
+			for _, v := range e.Violations {
+				s = append(s, v.String())
+			}
+		case *OutOfRangeFailure:
+			for _, v := range e.Violations {
+				s = append(s, v.String())
+			}
+		default:
+			continue
+		}
+		break
+	}
+	sort.Strings(s)
+	return s
+}
+
+// detailSet returns the sorted string representation of all details
+// attached to err
+func detailSet(err error) []string {
+	details := Details(err)
+	s := make([]string, len(details))
+	for i := range details {
+		s[i] = fmt.Sprintf("%+v", details[i])
+	}
+	sort.Strings(s)
+	return s
+}
+
+func matchStrings(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package errors
+
+// Merge appends the field violations of `other` into e. Identical
+// field+description pairs are de-duplicated and the first-seen order is
+// kept, so merging is deterministic.
+func (e *BadRequest) Merge(other *BadRequest) *BadRequest {
+	if other == nil {
+		return e
+	}
+	e.Violations = dedupFieldViolations(append(e.Violations, other.Violations...))
+	return e
+}
+
+// MergeBad combines the field violations of every BadRequest found in
+// `errs` into a single BadRequest, so multi-stage validation pipelines can
+// accumulate violations. Nil errors and errors that do not carry a
+// BadRequest are ignored.
+// MergeBad returns nil when no error carries a BadRequest.
+func MergeBad(errs ...error) error {
+	var merged *BadRequest
+	for _, err := range errs {
+		f, ok := AsBadRequest(err)
+		if !ok {
+			continue
+		}
+		if merged == nil {
+			merged = &BadRequest{OccurredAt: f.OccurredAt}
+		}
+		merged.Merge(f)
+	}
+	if merged == nil {
+		return nil
+	}
+	return merged
+}
+
+// dedupFieldViolations removes identical field+description pairs, keeping
+// the first occurrence of each
+func dedupFieldViolations(violations []*FieldViolation) []*FieldViolation {
+	seen := make(map[string]struct{}, len(violations))
+	out := violations[:0]
+	for _, v := range violations {
+		key := v.Field + "\x00" + v.Description
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
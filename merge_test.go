@@ -0,0 +1,85 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestMerge_Dedup(t *testing.T) {
+	a, _ := errors.AsBadRequest(errors.Bad(
+		&errors.FieldViolation{Field: "email", Description: "missing"},
+		&errors.FieldViolation{Field: "name", Description: "too long"},
+	))
+	b, _ := errors.AsBadRequest(errors.Bad(
+		&errors.FieldViolation{Field: "email", Description: "missing"},
+		&errors.FieldViolation{Field: "email", Description: "invalid"},
+	))
+
+	merged := a.Merge(b)
+	want := []struct {
+		field, description string
+	}{
+		{"email", "missing"},
+		{"name", "too long"},
+		{"email", "invalid"},
+	}
+	if len(merged.Violations) != len(want) {
+		t.Fatalf("expect %d violations, but got %d", len(want), len(merged.Violations))
+	}
+	for i, w := range want {
+		v := merged.Violations[i]
+		if v.Field != w.field || v.Description != w.description {
+			t.Errorf("violation %d: expect %s/%s, but got %s/%s",
+				i, w.field, w.description, v.Field, v.Description,
+			)
+		}
+	}
+}
+
+func TestMerge_Nil(t *testing.T) {
+	a, _ := errors.AsBadRequest(errors.Bad(
+		&errors.FieldViolation{Field: "email", Description: "missing"},
+	))
+
+	merged := a.Merge(nil)
+	if merged != a {
+		t.Errorf("expect the receiver back, but got %v", merged)
+	}
+	if len(merged.Violations) != 1 {
+		t.Errorf("expect 1 violation, but got %d", len(merged.Violations))
+	}
+}
+
+func TestMergeBad(t *testing.T) {
+	err := errors.MergeBad(
+		nil,
+		errors.Bad(&errors.FieldViolation{Field: "email", Description: "missing"}),
+		errors.NotFound,
+		errors.New("boom"),
+		errors.Bad(&errors.FieldViolation{Field: "name", Description: "too long"}),
+	)
+
+	f, ok := errors.AsBadRequest(err)
+	if !ok {
+		t.Fatalf("expect a bad request, but got %v", err)
+	}
+	if len(f.Violations) != 2 {
+		t.Fatalf("expect 2 violations, but got %d", len(f.Violations))
+	}
+	if f.Violations[0].Field != "email" || f.Violations[1].Field != "name" {
+		t.Errorf("expect first-seen order, but got %v", f.Violations)
+	}
+	if f.OccurredAt.IsZero() {
+		t.Errorf("expect the first OccurredAt to be kept, but got a zero time")
+	}
+}
+
+func TestMergeBad_NoBadRequest(t *testing.T) {
+	if err := errors.MergeBad(nil, errors.NotFound, errors.New("boom")); err != nil {
+		t.Errorf("expect nil when no error carries a BadRequest, but got %v", err)
+	}
+	if err := errors.MergeBad(); err != nil {
+		t.Errorf("expect nil without arguments, but got %v", err)
+	}
+}
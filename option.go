@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"time"
+)
+
+// Option configures an error under construction. Violations implement Option,
+// so constructors accept a mix of violations and generic options, e.g.
+//
+//	errors.ResourceExhausted(
+//		&errors.QuotaViolation{Subject: "read", Description: "Daily limit exceeded"},
+//		errors.WithRetryInfo(time.Hour),
+//	)
+type Option interface {
+	apply(err error) error
+}
+
+type optionFunc func(err error) error
+
+func (f optionFunc) apply(err error) error { return f(err) }
+
+// WithRetryInfo returns an option that tells the clients when they can retry
+// the failed request. It sets the retry delay on an `AvailabilityFailure`,
+// and attaches a `RetryInfo` detail to any other error.
+func WithRetryInfo(retryDelay time.Duration) Option {
+	return optionFunc(func(err error) error {
+		for e := err; e != nil; e = Unwrap(e) {
+			if f, ok := e.(*AvailabilityFailure); ok {
+				f.RetryInfo.RetryDelay = retryDelay
+				return err
+			}
+		}
+		return WithDetails(err, &RetryInfo{RetryDelay: retryDelay})
+	})
+}
+
+// WithHelp returns an option that attaches links to documentation or for
+// performing an out-of-band action.
+func WithHelp(links ...*HelpLink) Option {
+	return optionFunc(func(err error) error {
+		return WithDetails(err, &Help{Links: links})
+	})
+}
+
+// WithMetadata returns an option that attaches structured key/value facts
+// (e.g. tenant, shard, region) to the error.
+func WithMetadata(meta map[string]string) Option {
+	return optionFunc(func(err error) error {
+		m := make(Metadata, len(meta))
+		for k, v := range meta {
+			m[k] = v
+		}
+		return WithDetails(err, m)
+	})
+}
+
+// applyOptions applies the given options on err
+func applyOptions(err error, opts ...Option) error {
+	for _, opt := range opts {
+		err = opt.apply(err)
+	}
+	return err
+}
+
+// Violations append themselves to the first failure of their matching type
+// in the wrap chain. A violation applied to a failure of another type is
+// ignored.
+
+func (v *FieldViolation) apply(err error) error {
+	for e := err; e != nil; e = Unwrap(e) {
+		if f, ok := e.(*BadRequest); ok {
+			f.Violations = append(f.Violations, v)
+			break
+		}
+	}
+	return err
+}
+
+func (v *PreconditionViolation) apply(err error) error {
+	for e := err; e != nil; e = Unwrap(e) {
+		if f, ok := e.(*PreconditionFailure); ok {
+			f.Violations = append(f.Violations, v)
+			break
+		}
+	}
+	return err
+}
+
+func (v *ConflictViolation) apply(err error) error {
+	for e := err; e != nil; e = Unwrap(e) {
+		if f, ok := e.(*ConflictFailure); ok {
+			f.Violations = append(f.Violations, v)
+			break
+		}
+	}
+	return err
+}
+
+func (v *QuotaViolation) apply(err error) error {
+	for e := err; e != nil; e = Unwrap(e) {
+		if f, ok := e.(*QuotaFailure); ok {
+			f.Violations = append(f.Violations, v)
+			break
+		}
+	}
+	return err
+}
+
+func (v *RangeViolation) apply(err error) error {
+	for e := err; e != nil; e = Unwrap(e) {
+		if f, ok := e.(*OutOfRangeFailure); ok {
+			f.Violations = append(f.Violations, v)
+			break
+		}
+	}
+	return err
+}
@@ -0,0 +1,67 @@
+package errors
+
+import "strings"
+
+// pgvError is the interface implemented by the field validation errors
+// generated by protoc-gen-validate. The types themselves are generated per
+// message, so the adapter matches them structurally.
+type pgvError interface {
+	Field() string
+	Reason() string
+	Cause() error
+	ErrorName() string
+}
+
+// pgvMultiError is the interface implemented by the multi-errors generated
+// by protoc-gen-validate
+type pgvMultiError interface {
+	AllErrors() []error
+}
+
+// FromPGV converts protoc-gen-validate validation errors into a
+// `BadRequest` with one `FieldViolation` per failed field, using proto
+// field paths (e.g. "user.address[2].line"), so gRPC services can return
+// spec-compliant InvalidArgument details from generated validation.
+//
+// Errors that were not produced by protoc-gen-validate are returned
+// unchanged. If err is nil, FromPGV returns nil.
+func FromPGV(err error) error {
+	if err == nil {
+		return nil
+	}
+	violations := pgvViolations(err, "")
+	if len(violations) == 0 {
+		return err
+	}
+	return Bad(violations...)
+}
+
+// pgvViolations flattens single and multi validation errors into field
+// violations, descending into the causes of nested message validation
+func pgvViolations(err error, prefix string) []Option {
+	switch err := err.(type) {
+	case pgvMultiError:
+		var violations []Option
+		for _, e := range err.AllErrors() {
+			violations = append(violations, pgvViolations(e, prefix)...)
+		}
+		return violations
+	case pgvError:
+		field := err.Field()
+		if prefix != "" {
+			field = strings.Join([]string{prefix, field}, ".")
+		}
+		// A nested message failure carries the actual violation in its cause
+		if cause := err.Cause(); cause != nil {
+			if nested := pgvViolations(cause, field); len(nested) > 0 {
+				return nested
+			}
+		}
+		return []Option{&FieldViolation{
+			Field:       field,
+			Description: err.Reason(),
+		}}
+	default:
+		return nil
+	}
+}
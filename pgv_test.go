@@ -0,0 +1,91 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+// fakePGVError mimics a field validation error generated by
+// protoc-gen-validate
+type fakePGVError struct {
+	field  string
+	reason string
+	cause  error
+}
+
+func (e fakePGVError) Field() string     { return e.field }
+func (e fakePGVError) Reason() string    { return e.reason }
+func (e fakePGVError) Cause() error      { return e.cause }
+func (e fakePGVError) ErrorName() string { return "FakeValidationError" }
+func (e fakePGVError) Error() string     { return e.reason }
+
+// fakePGVMultiError mimics a multi-error generated by protoc-gen-validate
+type fakePGVMultiError []error
+
+func (e fakePGVMultiError) AllErrors() []error { return e }
+func (e fakePGVMultiError) Error() string      { return "validation failed" }
+
+func TestFromPGV(t *testing.T) {
+	err := errors.FromPGV(fakePGVError{
+		field:  "email",
+		reason: "value must be a valid email address",
+	})
+
+	f, ok := errors.AsBadRequest(err)
+	if !ok {
+		t.Fatalf("expect a bad request, but got %v", err)
+	}
+	if len(f.Violations) != 1 {
+		t.Fatalf("expect 1 violation, but got %d", len(f.Violations))
+	}
+	if f.Violations[0].Field != "email" {
+		t.Errorf("expect field email, but got %q", f.Violations[0].Field)
+	}
+	if f.Violations[0].Description != "value must be a valid email address" {
+		t.Errorf("expect the reason as description, but got %q", f.Violations[0].Description)
+	}
+}
+
+func TestFromPGV_Nested(t *testing.T) {
+	err := errors.FromPGV(fakePGVMultiError{
+		fakePGVError{field: "name", reason: "value length must be at most 64 runes"},
+		fakePGVError{
+			field:  "address",
+			reason: "embedded message failed validation",
+			cause: fakePGVError{
+				field:  "line",
+				reason: "value is required",
+			},
+		},
+	})
+
+	f, ok := errors.AsBadRequest(err)
+	if !ok {
+		t.Fatalf("expect a bad request, but got %v", err)
+	}
+	if len(f.Violations) != 2 {
+		t.Fatalf("expect 2 violations, but got %d", len(f.Violations))
+	}
+	if f.Violations[0].Field != "name" {
+		t.Errorf("expect field name, but got %q", f.Violations[0].Field)
+	}
+	// The nested cause carries the actual violation, prefixed with the path
+	// of the embedding field
+	if f.Violations[1].Field != "address.line" {
+		t.Errorf("expect field address.line, but got %q", f.Violations[1].Field)
+	}
+	if f.Violations[1].Description != "value is required" {
+		t.Errorf("expect the nested reason, but got %q", f.Violations[1].Description)
+	}
+}
+
+func TestFromPGV_Passthrough(t *testing.T) {
+	err := errors.New("boom")
+	if got := errors.FromPGV(err); got != err {
+		t.Errorf("expect non-validation errors unchanged, but got %v", got)
+	}
+	if got := errors.FromPGV(nil); got != nil {
+		t.Errorf("expect nil, but got %v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// PreconditionType describes a precondition violation type declared by a
+// service, e.g. "TOS" for "Terms of Service violation". Registering types
+// prevents typo'd violation types from leaking across service boundaries.
+type PreconditionType struct {
+	// The type constant, e.g. "TOS" or "KYC".
+	Type string
+	// A description of what the type covers.
+	Description string
+}
+
+var preconditionRegistry = struct {
+	mu    sync.RWMutex
+	types map[string]PreconditionType
+}{
+	types: map[string]PreconditionType{},
+}
+
+// RegisterPreconditionType declares a precondition violation type. It is
+// expected to be called at init time and panics when the type has already
+// been registered.
+func RegisterPreconditionType(violationType, description string) {
+	preconditionRegistry.mu.Lock()
+	defer preconditionRegistry.mu.Unlock()
+
+	if _, ok := preconditionRegistry.types[violationType]; ok {
+		panic(fmt.Sprintf("errors: precondition type %q registered twice", violationType))
+	}
+	preconditionRegistry.types[violationType] = PreconditionType{
+		Type:        violationType,
+		Description: description,
+	}
+}
+
+// LookupPreconditionType returns the registered precondition type, so
+// clients can resolve the types carried by a PreconditionFailure.
+func LookupPreconditionType(violationType string) (PreconditionType, bool) {
+	preconditionRegistry.mu.RLock()
+	defer preconditionRegistry.mu.RUnlock()
+
+	t, ok := preconditionRegistry.types[violationType]
+	return t, ok
+}
+
+// PreconditionTypes returns all registered precondition types, sorted by
+// type constant.
+func PreconditionTypes() []PreconditionType {
+	preconditionRegistry.mu.RLock()
+	defer preconditionRegistry.mu.RUnlock()
+
+	types := make([]PreconditionType, 0, len(preconditionRegistry.types))
+	for _, t := range preconditionRegistry.types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Type < types[j].Type })
+	return types
+}
+
+// Precondition returns a PreconditionViolation after validating its type
+// against the registry. It panics when the type has not been registered,
+// which catches typo'd violation types at development time.
+func Precondition(violationType, subject, description string) *PreconditionViolation {
+	if _, ok := LookupPreconditionType(violationType); !ok {
+		panic(fmt.Sprintf("errors: precondition type %q not registered", violationType))
+	}
+	return &PreconditionViolation{
+		Type:        violationType,
+		Subject:     subject,
+		Description: description,
+	}
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Recover converts a recovered panic into an InternalFailure carrying the
+// panic value and the captured stack as DebugInfo, for use in recovery
+// middleware and background jobs:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			err = errors.Recover(r)
+//		}
+//	}()
+//
+// When the panic value is an error, it is kept as the cause, so predicates
+// and Unwrap keep working. If recovered is nil, Recover returns nil.
+func Recover(recovered interface{}) error {
+	if recovered == nil {
+		return nil
+	}
+
+	cause, ok := recovered.(error)
+	if !ok {
+		cause = Errorf("%v", recovered)
+	}
+
+	stack := make([]byte, 64<<10)
+	stack = stack[:runtime.Stack(stack, false)]
+	return notify(&InternalFailure{
+		error:      cause,
+		OccurredAt: Now(),
+		DebugInfo: &DebugInfo{
+			StackEntries: strings.Split(strings.TrimRight(string(stack), "\n"), "\n"),
+			Detail:       fmt.Sprintf("panic: %v", recovered),
+		},
+	})
+}
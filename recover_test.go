@@ -0,0 +1,44 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestRecover(t *testing.T) {
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Recover(r)
+			}
+		}()
+		panic("unexpected state")
+	}()
+
+	f, ok := errors.AsInternalFailure(err)
+	if !ok {
+		t.Fatalf("expect an InternalFailure, but got %v", err)
+	}
+	if f.DebugInfo == nil || len(f.DebugInfo.StackEntries) == 0 {
+		t.Error("expect the captured stack to be attached")
+	}
+	if errors.CodeOf(err) != errors.CodeInternal {
+		t.Errorf("expect code %v, but got %v", errors.CodeInternal, errors.CodeOf(err))
+	}
+}
+
+func TestRecover_Error(t *testing.T) {
+	cause := errors.New("boom")
+	err := errors.Recover(cause)
+	if !errors.Is(err, cause) {
+		t.Errorf("expect the panic error to be kept as cause, but got %v", err)
+	}
+}
+
+func TestRecover_Nil(t *testing.T) {
+	if err := errors.Recover(nil); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// RedactPolicy transforms an error before it crosses a trust boundary.
+// Policies mutate the error in place; apply them through Redact, which
+// operates on a deep copy.
+type RedactPolicy func(err error) error
+
+// InternalMetaPrefix marks a metadata entry as internal-only. Entries whose
+// key carries the prefix are stripped by StripInternal.
+const InternalMetaPrefix = "internal:"
+
+// Redact returns a deep copy of `err` transformed by the given policies,
+// so errors can be sanitised before they cross a trust boundary without
+// mutating the original stored elsewhere.
+// If err is nil, Redact returns nil.
+func Redact(err error, policies ...RedactPolicy) error {
+	if err == nil {
+		return nil
+	}
+	err = Clone(err)
+	for _, p := range policies {
+		err = p(err)
+	}
+	return err
+}
+
+// StripInternal removes the internal wrap messages of an error, along with
+// the violations and metadata entries marked internal-only, so internal
+// context (e.g. database errors) does not leak to external clients. Errors
+// of unknown types are replaced by an opaque internal error.
+func StripInternal(err error) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *withDetails:
+		e.error = StripInternal(e.error)
+		details := e.details[:0]
+		for _, d := range e.details {
+			if m, ok := d.(Metadata); ok {
+				for k := range m {
+					if strings.HasPrefix(k, InternalMetaPrefix) {
+						delete(m, k)
+					}
+				}
+				if len(m) == 0 {
+					continue
+				}
+			}
+			details = append(details, d)
+		}
+		e.details = details
+	case *MultiFailure:
+		for i := range e.Errors {
+			e.Errors[i] = StripInternal(e.Errors[i])
+		}
+	case *BadRequest:
+		e.error = nil
+		violations := e.Violations[:0]
+		for _, v := range e.Violations {
+			if !v.Internal {
+				violations = append(violations, v)
+			}
+		}
+		e.Violations = violations
+	case *PreconditionFailure:
+		e.error = nil
+		violations := e.Violations[:0]
+		for _, v := range e.Violations {
+			if !v.Internal {
+				violations = append(violations, v)
+			}
+		}
+		e.Violations = violations
+	case *ConflictFailure:
+		e.error = nil
+		violations := e.Violations[:0]
+		for _, v := range e.Violations {
+			if !v.Internal {
+				violations = append(violations, v)
+			}
+		}
+		e.Violations = violations
+	case *QuotaFailure:
+		e.error = nil
+		violations := e.Violations[:0]
+		for _, v := range e.Violations {
+			if !v.Internal {
+				violations = append(violations, v)
+			}
+		}
+		e.Violations = violations
+	case *OutOfRangeFailure:
+		e.error = nil
+		violations := e.Violations[:0]
+		for _, v := range e.Violations {
+			if !v.Internal {
+				violations = append(violations, v)
+			}
+		}
+		e.Violations = violations
+	case *AvailabilityFailure:
+		e.error = nil
+	case *MissingFailure:
+		e.error = nil
+	case *PermissionFailure:
+		e.error = nil
+	case *AuthenticationFailure:
+		e.error = nil
+	case *DataLossFailure:
+		e.error = nil
+	case *InternalFailure:
+		e.error = nil
+	case *TimeoutFailure:
+		e.error = nil
+	case *CancelledFailure:
+		e.error = nil
+	case *NotImplementedFailure:
+		e.error = nil
+	case *ExistsFailure:
+		e.error = nil
+	default:
+		// Unknown errors carry their internal message in the clear
+		return New("internal")
+	}
+	return err
+}
+
+// StripDebug removes the debug information attached to an error (stack
+// entries and internal details), which should never leave the service that
+// produced it.
+func StripDebug(err error) error {
+	switch e := err.(type) {
+	case *withDetails:
+		e.error = StripDebug(e.error)
+		details := e.details[:0]
+		for _, d := range e.details {
+			if _, ok := d.(*DebugInfo); ok {
+				continue
+			}
+			details = append(details, d)
+		}
+		e.details = details
+	case *MultiFailure:
+		for i := range e.Errors {
+			e.Errors[i] = StripDebug(e.Errors[i])
+		}
+	case *DataLossFailure:
+		e.DebugInfo = nil
+	case *InternalFailure:
+		e.DebugInfo = nil
+	}
+	return err
+}
+
+// HashPII replaces the user-supplied values carried by field violations
+// with a truncated SHA-256 digest, so reports can still be correlated on
+// the value without exposing the data itself.
+func HashPII(err error) error {
+	switch e := err.(type) {
+	case *withDetails:
+		e.error = HashPII(e.error)
+	case *MultiFailure:
+		for i := range e.Errors {
+			e.Errors[i] = HashPII(e.Errors[i])
+		}
+	case *BadRequest:
+		for _, v := range e.Violations {
+			if v.Actual != "" {
+				v.Actual = hashValue(v.Actual)
+			}
+		}
+	}
+	return err
+}
+
+func hashValue(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,96 @@
+package errors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestRedact_StripInternal(t *testing.T) {
+	err := errors.WithMeta(
+		errors.Bad(
+			&errors.FieldViolation{Field: "email", Description: "invalid email address"},
+			&errors.FieldViolation{Field: "shard", Description: "shard 42 is readonly", Internal: true},
+		),
+		map[string]string{
+			"tenant":           "acme",
+			"internal:replica": "db-3",
+		},
+	)
+
+	got := errors.Redact(err, errors.StripInternal)
+
+	f, ok := errors.AsBadRequest(got)
+	if !ok {
+		t.Fatalf("expect a BadRequest, but got %v", got)
+	}
+	if len(f.Violations) != 1 || f.Violations[0].Field != "email" {
+		t.Errorf("expect only the public violation, but got %v", f.Violations)
+	}
+	meta := errors.Meta(got)
+	if _, ok := meta["internal:replica"]; ok {
+		t.Error("expect internal metadata to be stripped")
+	}
+	if meta["tenant"] != "acme" {
+		t.Errorf("expect public metadata to survive, but got %v", meta)
+	}
+
+	// The original must be left untouched
+	f, _ = errors.AsBadRequest(err)
+	if len(f.Violations) != 2 {
+		t.Errorf("expect the original violations to survive, but got %v", f.Violations)
+	}
+}
+
+func TestRedact_StripInternal_WrapMessage(t *testing.T) {
+	err := errors.WithNotFound(errors.New("SELECT failed on users table"))
+	got := errors.Redact(err, errors.StripInternal)
+
+	if !errors.IsNotFound(got) {
+		t.Fatalf("expect a not found error, but got %v", got)
+	}
+	if strings.Contains(got.Error(), "SELECT") {
+		t.Errorf("expect the internal message to be stripped, but got %q", got.Error())
+	}
+}
+
+func TestRedact_StripInternal_Opaque(t *testing.T) {
+	err := errors.New("pq: connection refused on 10.0.0.3")
+	got := errors.Redact(err, errors.StripInternal)
+
+	if strings.Contains(got.Error(), "10.0.0.3") {
+		t.Errorf("expect the internal message to be stripped, but got %q", got.Error())
+	}
+}
+
+func TestRedact_StripDebug(t *testing.T) {
+	err := errors.WithDetails(errors.Aborted(), &errors.DebugInfo{
+		StackEntries: []string{"main.go:42"},
+		Detail:       "lock owner goroutine 12",
+	})
+
+	got := errors.Redact(err, errors.StripDebug)
+	for _, d := range errors.Details(got) {
+		if _, ok := d.(*errors.DebugInfo); ok {
+			t.Error("expect debug info to be stripped")
+		}
+	}
+}
+
+func TestRedact_HashPII(t *testing.T) {
+	err := errors.Bad(&errors.FieldViolation{
+		Field:       "email",
+		Description: "email already registered",
+		Actual:      "jane@example.org",
+	})
+
+	got := errors.Redact(err, errors.HashPII)
+	f, _ := errors.AsBadRequest(got)
+	if f.Violations[0].Actual == "jane@example.org" {
+		t.Error("expect the actual value to be hashed")
+	}
+	if !strings.HasPrefix(f.Violations[0].Actual, "sha256:") {
+		t.Errorf("expect a sha256 digest, but got %q", f.Violations[0].Actual)
+	}
+}
@@ -0,0 +1,125 @@
+// Package registry declares stable, organisation-wide error codes. Teams
+// register their codes at init time, constructors stamp errors with them,
+// and reporters resolve the domain, severity and documentation of a failure
+// from the code rather than from its message, so grouping and alerting
+// survive message wording changes.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/deixis/errors"
+)
+
+// Severity is the default severity of a registered error code, used by
+// reporters and alerting.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "INFO"
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityError:
+		return "ERROR"
+	case SeverityCritical:
+		return "CRITICAL"
+	}
+	return "UNKNOWN"
+}
+
+// Definition describes a stable error code declared by a team.
+type Definition struct {
+	// Code is the stable identifier, e.g. "billing.card_expired". It must
+	// never change once released, unlike the error message.
+	Code string
+	// Domain namespaces the code, e.g. "billing".
+	Domain string
+	// Severity is the default severity used by reporters.
+	Severity Severity
+	// DocsURL points to the runbook or documentation of the failure.
+	DocsURL string
+}
+
+var registry = struct {
+	mu    sync.RWMutex
+	codes map[string]Definition
+}{
+	codes: map[string]Definition{},
+}
+
+// Register declares an error code. It is expected to be called at init time
+// and panics when the code is empty or has already been registered.
+func Register(def Definition) {
+	if def.Code == "" {
+		panic("registry: cannot register an empty code")
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.codes[def.Code]; ok {
+		panic(fmt.Sprintf("registry: code %q registered twice", def.Code))
+	}
+	registry.codes[def.Code] = def
+}
+
+// Lookup returns the definition registered under the given code.
+func Lookup(code string) (Definition, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	def, ok := registry.codes[code]
+	return def, ok
+}
+
+// Definitions returns all registered definitions, sorted by code.
+func Definitions() []Definition {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	defs := make([]Definition, 0, len(registry.codes))
+	for _, def := range registry.codes {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Code < defs[j].Code })
+	return defs
+}
+
+// Ref is the detail attached to an error stamped with a registered code.
+type Ref struct {
+	// The stable code, e.g. "billing.card_expired".
+	Code string
+}
+
+// Stamp annotates `err` with a registered error code after validating it
+// against the registry. It panics when the code has not been registered,
+// which catches typo'd codes at development time.
+// If err is nil, Stamp returns nil.
+func Stamp(err error, code string) error {
+	if _, ok := Lookup(code); !ok {
+		panic(fmt.Sprintf("registry: code %q not registered", code))
+	}
+	return errors.WithDetails(err, &Ref{Code: code})
+}
+
+// Code resolves the definition of the code stamped on `err` or the errors
+// it wraps.
+func Code(err error) (Definition, bool) {
+	for _, d := range errors.Details(err) {
+		if ref, ok := d.(*Ref); ok {
+			return Lookup(ref.Code)
+		}
+	}
+	return Definition{}, false
+}
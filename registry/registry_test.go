@@ -0,0 +1,50 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/registry"
+)
+
+func init() {
+	registry.Register(registry.Definition{
+		Code:     "billing.card_expired",
+		Domain:   "billing",
+		Severity: registry.SeverityWarning,
+		DocsURL:  "https://docs.example.org/errors/billing#card_expired",
+	})
+}
+
+func TestStamp(t *testing.T) {
+	err := registry.Stamp(errors.FailedPrecondition(), "billing.card_expired")
+
+	def, ok := registry.Code(err)
+	if !ok {
+		t.Fatalf("expect a registered code, but got none")
+	}
+	if def.Domain != "billing" {
+		t.Errorf("expect domain %q, but got %q", "billing", def.Domain)
+	}
+	if def.Severity != registry.SeverityWarning {
+		t.Errorf("expect severity %v, but got %v", registry.SeverityWarning, def.Severity)
+	}
+}
+
+func TestStamp_Unregistered(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect Stamp to panic on an unregistered code")
+		}
+	}()
+	registry.Stamp(errors.Aborted(), "billing.unknown")
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect Register to panic on a duplicate code")
+		}
+	}()
+	registry.Register(registry.Definition{Code: "billing.card_expired"})
+}
@@ -0,0 +1,160 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// Render substitutes the `{name}` placeholders in the message with the given
+// arguments, e.g. "retry in {n} seconds" with args {"n": 30}. Plural blocks
+// select a branch with the CLDR plural rules of the message locale:
+//
+//	{n, plural, one {# second} other {# seconds}}
+//
+// where `#` stands for the value itself. Placeholders without a matching
+// argument are left untouched.
+func (m *LocalisedMessage) Render(args map[string]interface{}) string {
+	return renderTemplate(m.Message, m.Locale, args)
+}
+
+// Render matches the best supported language based on the preferred list
+// (see Match) and substitutes the `{name}` placeholders of the matched
+// translation with the given arguments. The first preferred locale drives
+// the CLDR plural rules.
+func (s LocalisedString) Render(args map[string]interface{}, locales ...string) string {
+	locale := language.Und
+	if len(locales) > 0 {
+		if t, err := language.Parse(locales[0]); err == nil {
+			locale = t
+		}
+	}
+	msg, err := s.Match(locales...)
+	if err != nil {
+		return ""
+	}
+	return renderTemplate(msg, locale, args)
+}
+
+func renderTemplate(msg string, locale language.Tag, args map[string]interface{}) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); {
+		if msg[i] != '{' {
+			b.WriteByte(msg[i])
+			i++
+			continue
+		}
+		end := matchBrace(msg, i)
+		if end < 0 {
+			b.WriteString(msg[i:])
+			break
+		}
+		b.WriteString(renderPlaceholder(msg[i+1:end], locale, args))
+		i = end + 1
+	}
+	return b.String()
+}
+
+// matchBrace returns the index of the brace closing the one at start,
+// accounting for nested braces, or -1 when it is unbalanced
+func matchBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// renderPlaceholder renders the body of a `{...}` placeholder, which is
+// either a plain argument reference or a plural block
+func renderPlaceholder(body string, locale language.Tag, args map[string]interface{}) string {
+	parts := strings.SplitN(body, ",", 3)
+	name := strings.TrimSpace(parts[0])
+	v, ok := args[name]
+	if !ok {
+		return "{" + body + "}"
+	}
+	if len(parts) < 3 || strings.TrimSpace(parts[1]) != "plural" {
+		return fmt.Sprint(v)
+	}
+
+	branches := parsePluralBranches(parts[2])
+	branch, ok := branches[pluralForm(locale, v)]
+	if !ok {
+		if branch, ok = branches[plural.Other]; !ok {
+			return fmt.Sprint(v)
+		}
+	}
+	return strings.Replace(branch, "#", fmt.Sprint(v), -1)
+}
+
+// parsePluralBranches parses a sequence of `form {message}` branches
+func parsePluralBranches(s string) map[plural.Form]string {
+	branches := map[plural.Form]string{}
+	for i := 0; i < len(s); {
+		open := strings.IndexByte(s[i:], '{')
+		if open < 0 {
+			break
+		}
+		open += i
+		end := matchBrace(s, open)
+		if end < 0 {
+			break
+		}
+		if form, ok := pluralFormName(strings.TrimSpace(s[i:open])); ok {
+			branches[form] = s[open+1 : end]
+		}
+		i = end + 1
+	}
+	return branches
+}
+
+func pluralFormName(s string) (plural.Form, bool) {
+	switch s {
+	case "zero":
+		return plural.Zero, true
+	case "one":
+		return plural.One, true
+	case "two":
+		return plural.Two, true
+	case "few":
+		return plural.Few, true
+	case "many":
+		return plural.Many, true
+	case "other":
+		return plural.Other, true
+	}
+	return plural.Other, false
+}
+
+// pluralForm resolves the CLDR plural form of v for the given locale. Values
+// that are not numbers fall back to the "other" form.
+func pluralForm(locale language.Tag, v interface{}) plural.Form {
+	s := fmt.Sprint(v)
+	s = strings.TrimPrefix(s, "-")
+	parts := strings.SplitN(s, ".", 2)
+
+	i, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return plural.Other
+	}
+	if len(parts) == 1 {
+		return plural.Cardinal.MatchPlural(locale, i, 0, 0, 0, 0)
+	}
+	f, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return plural.Other
+	}
+	return plural.Cardinal.MatchPlural(locale, i, len(parts[1]), len(parts[1]), f, f)
+}
@@ -0,0 +1,83 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+	"golang.org/x/text/language"
+)
+
+func TestLocalisedMessage_Render(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    errors.LocalisedMessage
+		args   map[string]interface{}
+		expect string
+	}{
+		{
+			name: "substitution",
+			msg: errors.LocalisedMessage{
+				Locale:  language.English,
+				Message: "field {field} must be below {limit}",
+			},
+			args:   map[string]interface{}{"field": "age", "limit": 150},
+			expect: "field age must be below 150",
+		},
+		{
+			name: "missing argument left untouched",
+			msg: errors.LocalisedMessage{
+				Locale:  language.English,
+				Message: "retry in {n} seconds",
+			},
+			args:   map[string]interface{}{},
+			expect: "retry in {n} seconds",
+		},
+		{
+			name: "plural one",
+			msg: errors.LocalisedMessage{
+				Locale:  language.English,
+				Message: "retry in {n, plural, one {# second} other {# seconds}}",
+			},
+			args:   map[string]interface{}{"n": 1},
+			expect: "retry in 1 second",
+		},
+		{
+			name: "plural other",
+			msg: errors.LocalisedMessage{
+				Locale:  language.English,
+				Message: "retry in {n, plural, one {# second} other {# seconds}}",
+			},
+			args:   map[string]interface{}{"n": 30},
+			expect: "retry in 30 seconds",
+		},
+		{
+			name: "plural many for locale with complex rules",
+			msg: errors.LocalisedMessage{
+				Locale:  language.Polish,
+				Message: "{n, plural, one {# plik} few {# pliki} many {# plików} other {# pliku}}",
+			},
+			args:   map[string]interface{}{"n": 5},
+			expect: "5 plików",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.msg.Render(test.args); got != test.expect {
+				t.Errorf("expect message %q, but got %q", test.expect, got)
+			}
+		})
+	}
+}
+
+func TestLocalisedString_Render(t *testing.T) {
+	s := errors.LocalisedString{
+		"en": "{n, plural, one {# item} other {# items}}",
+		"fr": "{n, plural, one {# élément} other {# éléments}}",
+	}
+
+	got := s.Render(map[string]interface{}{"n": 2}, "fr-CH")
+	if expect := "2 éléments"; got != expect {
+		t.Errorf("expect message %q, but got %q", expect, got)
+	}
+}
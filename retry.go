@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"context"
+	"time"
+)
+
+// Retryable is implemented by errors that know whether the operation that
+// produced them can be retried.
+type Retryable interface {
+	// Retryable reports whether the failed operation can be retried
+	Retryable() bool
+}
+
+// IsRetryable reports whether the operation that produced `err` can be
+// retried, possibly after the delay returned by RetryAfter.
+//
+// An error is retryable when any error in its chain implements Retryable
+// and reports so, or when it is one of AvailabilityFailure, QuotaFailure,
+// ConflictFailure, or a deadline expiry.
+func IsRetryable(err error) bool {
+	for e := err; e != nil; e = Unwrap(e) {
+		if r, ok := e.(Retryable); ok {
+			return r.Retryable()
+		}
+
+		switch e {
+		case context.DeadlineExceeded:
+			return true
+		case context.Canceled:
+			// The caller walked away, so there is no point retrying
+			return false
+		}
+
+		switch e.(type) {
+		case *AvailabilityFailure, *QuotaFailure, *ConflictFailure, *TimeoutFailure:
+			return true
+		}
+	}
+	return false
+}
+
+// RetryAfter returns the delay clients should wait before retrying the
+// operation that produced `err`. The result honours RetryAt and is spread
+// within the jitter window (see RetryInfo.Delay), so many clients
+// receiving the same recommendation do not retry in lockstep.
+// It returns 0 when the error carries no recommendation, in which case
+// clients should fall back to their own backoff strategy.
+func RetryAfter(err error) time.Duration {
+	for _, d := range Details(err) {
+		if info, ok := d.(*RetryInfo); ok {
+			return info.Delay(Now())
+		}
+	}
+	for e := err; e != nil; e = Unwrap(e) {
+		if f, ok := e.(*AvailabilityFailure); ok {
+			return f.RetryInfo.Delay(Now())
+		}
+	}
+	return 0
+}
@@ -0,0 +1,88 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/deixis/errors"
+)
+
+// stubRetryable wraps an error and pins its own retry decision
+type stubRetryable struct {
+	error
+	retryable bool
+}
+
+func (e *stubRetryable) Retryable() bool { return e.retryable }
+func (e *stubRetryable) Unwrap() error   { return e.error }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain", errors.New("boom"), false},
+		{"not found", errors.NotFound, false},
+		{"bad request", errors.Bad(), false},
+		{"unavailable", errors.Unavailable(time.Second), true},
+		{"resource exhausted", errors.ResourceExhausted(), true},
+		{"aborted", errors.Aborted(), true},
+		{"timeout", errors.WithDeadlineExceeded(nil), true},
+		{"deadline sentinel", context.DeadlineExceeded, true},
+		{"canceled sentinel", context.Canceled, false},
+		{"wrapped unavailable", fmt.Errorf("call: %w", errors.Unavailable(0)), true},
+		{"wrapped canceled", errors.Wrap(context.Canceled, "call"), false},
+		{
+			// The Retryable interface wins over the classification of the
+			// errors it wraps
+			name: "retryable override",
+			err:  &stubRetryable{error: errors.Unavailable(0), retryable: false},
+			want: false,
+		},
+		{
+			name: "retryable opt-in",
+			err:  &stubRetryable{error: errors.New("boom"), retryable: true},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		if got := errors.IsRetryable(test.err); got != test.want {
+			t.Errorf("%s: expect %t, but got %t", test.name, test.want, got)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d := errors.RetryAfter(errors.Unavailable(30 * time.Second)); d != 30*time.Second {
+		t.Errorf("expect 30s from the availability failure, but got %v", d)
+	}
+
+	err := errors.WithDetails(errors.Aborted(), &errors.RetryInfo{
+		RetryDelay: 5 * time.Second,
+	})
+	if d := errors.RetryAfter(err); d != 5*time.Second {
+		t.Errorf("expect 5s from the retry info detail, but got %v", d)
+	}
+
+	// RetryAt takes precedence over the delay and never yields a negative
+	// wait once it has passed
+	past := errors.WithDetails(errors.Aborted(), &errors.RetryInfo{
+		RetryDelay: 5 * time.Second,
+		RetryAt:    time.Now().Add(-time.Second),
+	})
+	if d := errors.RetryAfter(past); d != 0 {
+		t.Errorf("expect 0 once the retry time has passed, but got %v", d)
+	}
+
+	if d := errors.RetryAfter(errors.New("boom")); d != 0 {
+		t.Errorf("expect 0 without a recommendation, but got %v", d)
+	}
+	if d := errors.RetryAfter(nil); d != 0 {
+		t.Errorf("expect 0 for nil, but got %v", d)
+	}
+}
@@ -0,0 +1,62 @@
+package sqlclass
+
+import (
+	"strings"
+
+	"github.com/deixis/errors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error numbers
+const (
+	mysqlDuplicateEntry   = 1062
+	mysqlRowIsReferenced  = 1451
+	mysqlNoReferencedRow  = 1452
+	mysqlDeadlockDetected = 1213
+)
+
+func init() {
+	errors.RegisterSQLClassifier(classifyMySQL)
+}
+
+// classifyMySQL classifies the errors returned by the go-sql-driver/mysql
+// driver
+func classifyMySQL(err error) (error, bool) {
+	var myErr *mysql.MySQLError
+	if !errors.As(err, &myErr) {
+		return nil, false
+	}
+
+	switch myErr.Number {
+	case mysqlDuplicateEntry:
+		return errors.WithAborted(err, &errors.ConflictViolation{
+			Resource:    mysqlKey(myErr.Message),
+			Description: myErr.Message,
+		}), true
+	case mysqlRowIsReferenced, mysqlNoReferencedRow:
+		return errors.WithFailedPrecondition(err, &errors.PreconditionViolation{
+			Type:        "FOREIGN_KEY",
+			Subject:     mysqlKey(myErr.Message),
+			Description: myErr.Message,
+		}), true
+	case mysqlDeadlockDetected:
+		return errors.WithAborted(err), true
+	}
+	return nil, false
+}
+
+// mysqlKey extracts the constraint name from a MySQL error message, e.g.
+// "Duplicate entry 'jane' for key 'users.email'". MySQL does not expose the
+// constraint name as a structured field.
+func mysqlKey(msg string) string {
+	const marker = "for key '"
+	i := strings.Index(msg, marker)
+	if i < 0 {
+		return ""
+	}
+	rest := msg[i+len(marker):]
+	if j := strings.IndexByte(rest, '\''); j >= 0 {
+		return rest[:j]
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package sqlclass
+
+import (
+	"github.com/deixis/errors"
+	"github.com/jackc/pgconn"
+)
+
+func init() {
+	errors.RegisterSQLClassifier(classifyPGX)
+}
+
+// classifyPGX classifies the errors returned by the pgx driver
+func classifyPGX(err error) (error, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil, false
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return errors.WithAborted(err, &errors.ConflictViolation{
+			Resource:    pgErr.ConstraintName,
+			Description: pgErr.Message,
+		}), true
+	case pgForeignKeyViolation:
+		return errors.WithFailedPrecondition(err, &errors.PreconditionViolation{
+			Type:        "FOREIGN_KEY",
+			Subject:     pgErr.ConstraintName,
+			Description: pgErr.Message,
+		}), true
+	case pgSerializationError, pgDeadlockDetected:
+		return errors.WithAborted(err), true
+	}
+	return nil, false
+}
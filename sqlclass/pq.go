@@ -0,0 +1,35 @@
+package sqlclass
+
+import (
+	"github.com/deixis/errors"
+	"github.com/lib/pq"
+)
+
+func init() {
+	errors.RegisterSQLClassifier(classifyPQ)
+}
+
+// classifyPQ classifies the errors returned by the lib/pq driver
+func classifyPQ(err error) (error, bool) {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return nil, false
+	}
+
+	switch string(pqErr.Code) {
+	case pgUniqueViolation:
+		return errors.WithAborted(err, &errors.ConflictViolation{
+			Resource:    pqErr.Constraint,
+			Description: pqErr.Message,
+		}), true
+	case pgForeignKeyViolation:
+		return errors.WithFailedPrecondition(err, &errors.PreconditionViolation{
+			Type:        "FOREIGN_KEY",
+			Subject:     pqErr.Constraint,
+			Description: pqErr.Message,
+		}), true
+	case pgSerializationError, pgDeadlockDetected:
+		return errors.WithAborted(err), true
+	}
+	return nil, false
+}
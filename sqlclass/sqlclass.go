@@ -0,0 +1,19 @@
+// Package sqlclass registers driver-specific SQL error classifiers with
+// errors.FromSQL. Importing the package for side effects is enough:
+//
+//	import _ "github.com/deixis/errors/sqlclass"
+//
+// Unique-constraint and serialisation failures map to a ConflictFailure and
+// foreign-key violations to a FailedPrecondition, with the constraint name
+// as the violation Resource/Subject, so data layers do not have to
+// reimplement the mapping per driver. Classifiers are provided for lib/pq,
+// pgx and go-sql-driver/mysql.
+package sqlclass
+
+// SQLSTATE classes shared by the PostgreSQL drivers
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgSerializationError  = "40001"
+	pgDeadlockDetected    = "40P01"
+)
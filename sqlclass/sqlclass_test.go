@@ -0,0 +1,134 @@
+package sqlclass_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/deixis/errors"
+	_ "github.com/deixis/errors/sqlclass"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+)
+
+func TestFromSQL_UniqueViolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		resource string
+	}{
+		{
+			name: "pq",
+			err: &pq.Error{
+				Code:       "23505",
+				Message:    "duplicate key value violates unique constraint \"users_email_key\"",
+				Constraint: "users_email_key",
+			},
+			resource: "users_email_key",
+		},
+		{
+			name: "pgx",
+			err: &pgconn.PgError{
+				Code:           "23505",
+				Message:        "duplicate key value violates unique constraint \"users_email_key\"",
+				ConstraintName: "users_email_key",
+			},
+			resource: "users_email_key",
+		},
+		{
+			name: "mysql",
+			err: &mysql.MySQLError{
+				Number:  1062,
+				Message: "Duplicate entry 'jane@example.org' for key 'users.email'",
+			},
+			resource: "users.email",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := errors.FromSQL(fmt.Errorf("insert user: %w", test.err))
+			f, ok := errors.AsConflictFailure(got)
+			if !ok {
+				t.Fatalf("expect a ConflictFailure, but got %v", got)
+			}
+			if f.Violations[0].Resource != test.resource {
+				t.Errorf("expect resource %s, but got %s",
+					test.resource, f.Violations[0].Resource,
+				)
+			}
+		})
+	}
+}
+
+func TestFromSQL_ForeignKeyViolation(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		subject string
+	}{
+		{
+			name: "pq",
+			err: &pq.Error{
+				Code:       "23503",
+				Message:    "insert or update on table \"orders\" violates foreign key constraint \"orders_user_id_fkey\"",
+				Constraint: "orders_user_id_fkey",
+			},
+			subject: "orders_user_id_fkey",
+		},
+		{
+			name: "pgx",
+			err: &pgconn.PgError{
+				Code:           "23503",
+				Message:        "insert or update on table \"orders\" violates foreign key constraint \"orders_user_id_fkey\"",
+				ConstraintName: "orders_user_id_fkey",
+			},
+			subject: "orders_user_id_fkey",
+		},
+		{
+			name: "mysql",
+			err: &mysql.MySQLError{
+				Number:  1452,
+				Message: "Cannot add or update a child row: a foreign key constraint fails",
+			},
+			subject: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := errors.FromSQL(test.err)
+			f, ok := errors.AsPreconditionFailure(got)
+			if !ok {
+				t.Fatalf("expect a PreconditionFailure, but got %v", got)
+			}
+			v := f.Violations[0]
+			if v.Type != "FOREIGN_KEY" {
+				t.Errorf("expect type FOREIGN_KEY, but got %s", v.Type)
+			}
+			if v.Subject != test.subject {
+				t.Errorf("expect subject %s, but got %s", test.subject, v.Subject)
+			}
+		})
+	}
+}
+
+func TestFromSQL_Retryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "pq serialization", err: &pq.Error{Code: "40001"}},
+		{name: "pgx deadlock", err: &pgconn.PgError{Code: "40P01"}},
+		{name: "mysql deadlock", err: &mysql.MySQLError{Number: 1213}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := errors.FromSQL(test.err)
+			if code := errors.CodeOf(got); code != errors.CodeAborted {
+				t.Errorf("expect code %v, but got %v", errors.CodeAborted, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"context"
+
+	lcontext "github.com/deixis/spine/context"
+)
+
+// Correlation carries the identifier that joins an error to the request
+// that produced it, e.g. the spine transit UUID.
+type Correlation struct {
+	// The unique identifier of the request.
+	ID string
+}
+
+// WithTransit annotates `err` with the transit UUID carried by `ctx`, so
+// error payloads and logs can be joined without extra plumbing. When ctx
+// carries no transit, err is returned unchanged.
+// If err is nil, WithTransit returns nil.
+func WithTransit(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	t := lcontext.TransitFromContext(ctx)
+	if t == nil {
+		return err
+	}
+	return WithDetails(err, &Correlation{ID: t.UUID()})
+}
+
+// CorrelationID returns the correlation ID attached to `err` or the errors
+// it wraps. It returns an empty string when no correlation ID is attached.
+func CorrelationID(err error) string {
+	for _, d := range Details(err) {
+		if c, ok := d.(*Correlation); ok {
+			return c.ID
+		}
+	}
+	return ""
+}
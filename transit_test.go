@@ -0,0 +1,43 @@
+package errors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deixis/errors"
+	lcontext "github.com/deixis/spine/context"
+)
+
+func TestWithTransit(t *testing.T) {
+	ctx, tr := lcontext.NewTransitWithContext(context.Background())
+
+	err := errors.WithTransit(ctx, errors.NotFound)
+	if id := errors.CorrelationID(err); id != tr.UUID() {
+		t.Errorf("expect correlation ID %s, but got %s", tr.UUID(), id)
+	}
+	if !errors.IsNotFound(err) {
+		t.Errorf("expect the annotated error to keep matching, but got %v", err)
+	}
+}
+
+func TestWithTransit_NoTransit(t *testing.T) {
+	err := errors.New("boom")
+	if got := errors.WithTransit(context.Background(), err); got != err {
+		t.Errorf("expect the error unchanged without a transit, but got %v", got)
+	}
+
+	ctx, _ := lcontext.NewTransitWithContext(context.Background())
+	if got := errors.WithTransit(ctx, nil); got != nil {
+		t.Errorf("expect nil, but got %v", got)
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	err := errors.WithDetails(errors.New("boom"), &errors.Correlation{ID: "req-1"})
+	if id := errors.CorrelationID(err); id != "req-1" {
+		t.Errorf("expect correlation ID req-1, but got %s", id)
+	}
+	if id := errors.CorrelationID(errors.New("boom")); id != "" {
+		t.Errorf("expect an empty correlation ID, but got %s", id)
+	}
+}
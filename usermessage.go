@@ -0,0 +1,30 @@
+package errors
+
+// userMessage carries the message clients should see instead of the full
+// wrapped chain. It stays unexported; transports read it through
+// UserMessage.
+type userMessage struct {
+	Message string
+}
+
+// WithUserMessage attaches a message intended for the end user, separate
+// from Error(). Transports (httperrors.Marshal, grpcerrors.Pack) emit the
+// user message as the status message, while logs keep the full internal
+// chain. If err is nil, WithUserMessage returns nil.
+func WithUserMessage(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return WithDetails(err, &userMessage{Message: msg})
+}
+
+// UserMessage returns the user-facing message attached to `err` or the
+// errors it wraps. It returns an empty string when none is attached.
+func UserMessage(err error) string {
+	for _, d := range Details(err) {
+		if m, ok := d.(*userMessage); ok {
+			return m.Message
+		}
+	}
+	return ""
+}
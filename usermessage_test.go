@@ -0,0 +1,43 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestUserMessage(t *testing.T) {
+	err := errors.Wrap(errors.New("pg: connection refused"), "load profile")
+	err = errors.WithUserMessage(errors.WithUnavailable(err, 0), "Please try again later")
+
+	if msg := errors.UserMessage(err); msg != "Please try again later" {
+		t.Errorf("expect the user message, but got %s", msg)
+	}
+	if msg := err.Error(); msg == "Please try again later" {
+		t.Errorf("expect Error() to keep the internal chain, but got %s", msg)
+	}
+}
+
+func TestUserMessage_JSONRoundTrip(t *testing.T) {
+	err := errors.WithUserMessage(errors.Aborted(), "This booking was modified by someone else")
+	data, jerr := errors.MarshalJSON(err)
+	if jerr != nil {
+		t.Fatalf("expect to marshal error, but got %v", jerr)
+	}
+	got, jerr := errors.UnmarshalJSON(data)
+	if jerr != nil {
+		t.Fatalf("expect to unmarshal error, but got %v", jerr)
+	}
+	if msg := errors.UserMessage(got); msg != "This booking was modified by someone else" {
+		t.Errorf("expect the user message to survive, but got %s", msg)
+	}
+}
+
+func TestUserMessage_None(t *testing.T) {
+	if msg := errors.UserMessage(errors.New("boom")); msg != "" {
+		t.Errorf("expect an empty user message, but got %s", msg)
+	}
+	if err := errors.WithUserMessage(nil, "nope"); err != nil {
+		t.Errorf("expect nil error, but got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package validatorerrors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deixis/errors"
+	validator "github.com/go-playground/validator/v10"
+)
+
+// FromValidator converts go-playground/validator validation failures into a
+// `BadRequest` with one `FieldViolation` per failed field. The field path
+// honours the names registered on the validator (e.g. json tags via
+// RegisterTagNameFunc). Additional options are applied to the resulting
+// error.
+//
+// Errors that are not validator.ValidationErrors are returned unchanged.
+// If err is nil, FromValidator returns nil.
+func FromValidator(err error, opts ...errors.Option) error {
+	if err == nil {
+		return nil
+	}
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	options := make([]errors.Option, 0, len(verrs)+len(opts))
+	for _, fe := range verrs {
+		options = append(options, &errors.FieldViolation{
+			Field:       fieldPath(fe),
+			Description: description(fe),
+			Reason:      strings.ToUpper(fe.Tag()),
+			Expected:    fe.Param(),
+			Actual:      fmt.Sprintf("%v", fe.Value()),
+		})
+	}
+	options = append(options, opts...)
+	return errors.Bad(options...)
+}
+
+// fieldPath returns the path of the failed field, without the leading
+// struct name
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
+
+// description returns a human readable description of the failure
+func description(fe validator.FieldError) string {
+	if fe.Param() != "" {
+		return fmt.Sprintf("failed on the '%s=%s' rule", fe.Tag(), fe.Param())
+	}
+	return fmt.Sprintf("failed on the '%s' rule", fe.Tag())
+}
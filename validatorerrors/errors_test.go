@@ -0,0 +1,68 @@
+package validatorerrors_test
+
+import (
+	"testing"
+
+	"github.com/deixis/errors"
+	"github.com/deixis/errors/validatorerrors"
+	validator "github.com/go-playground/validator/v10"
+)
+
+func TestFromValidator(t *testing.T) {
+	type signupRequest struct {
+		Email string `validate:"required,email"`
+		Age   int    `validate:"gte=18"`
+	}
+
+	verr := validator.New().Struct(signupRequest{
+		Email: "not-an-email",
+		Age:   16,
+	})
+	err := validatorerrors.FromValidator(verr)
+
+	f, ok := errors.AsBadRequest(err)
+	if !ok {
+		t.Fatalf("expect a bad request, but got %v", err)
+	}
+	if len(f.Violations) != 2 {
+		t.Fatalf("expect 2 violations, but got %d", len(f.Violations))
+	}
+
+	email := f.Violations[0]
+	if email.Field != "Email" {
+		t.Errorf("expect field Email, but got %q", email.Field)
+	}
+	if email.Description != "failed on the 'email' rule" {
+		t.Errorf("expect the rule description, but got %q", email.Description)
+	}
+	if email.Reason != "EMAIL" {
+		t.Errorf("expect reason EMAIL, but got %q", email.Reason)
+	}
+	if email.Actual != "not-an-email" {
+		t.Errorf("expect actual not-an-email, but got %q", email.Actual)
+	}
+
+	age := f.Violations[1]
+	if age.Field != "Age" {
+		t.Errorf("expect field Age, but got %q", age.Field)
+	}
+	if age.Description != "failed on the 'gte=18' rule" {
+		t.Errorf("expect the rule description with its parameter, but got %q", age.Description)
+	}
+	if age.Reason != "GTE" {
+		t.Errorf("expect reason GTE, but got %q", age.Reason)
+	}
+	if age.Expected != "18" {
+		t.Errorf("expect expected 18, but got %q", age.Expected)
+	}
+}
+
+func TestFromValidator_Passthrough(t *testing.T) {
+	err := errors.New("boom")
+	if got := validatorerrors.FromValidator(err); got != err {
+		t.Errorf("expect non-validation errors unchanged, but got %v", got)
+	}
+	if got := validatorerrors.FromValidator(nil); got != nil {
+		t.Errorf("expect nil, but got %v", got)
+	}
+}
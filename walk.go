@@ -0,0 +1,24 @@
+package errors
+
+// Walk calls `fn` for `err` and each error it wraps, innermost last, until
+// fn returns false. It traverses the causes hidden behind the embedded
+// error fields of failure structs, as well as errors wrapped with the
+// standard library or pkg/errors.
+func Walk(err error, fn func(error) bool) {
+	for e := err; e != nil; e = Unwrap(e) {
+		if !fn(e) {
+			return
+		}
+	}
+}
+
+// Root returns the innermost error of the chain of `err`, i.e. the original
+// cause of the failure. It returns nil when err is nil.
+func Root(err error) error {
+	var root error
+	Walk(err, func(e error) bool {
+		root = e
+		return true
+	})
+	return root
+}
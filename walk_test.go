@@ -0,0 +1,81 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestWalk(t *testing.T) {
+	root := errors.New("boom")
+	err := fmt.Errorf("load user: %w", errors.WithDetails(
+		errors.Wrap(root, "query"), "x",
+	))
+
+	var chain []error
+	errors.Walk(err, func(e error) bool {
+		chain = append(chain, e)
+		return true
+	})
+
+	if len(chain) < 2 {
+		t.Fatalf("expect Walk to traverse the chain, but got %d errors", len(chain))
+	}
+	if chain[0] != err {
+		t.Errorf("expect the outermost error first, but got %v", chain[0])
+	}
+	if chain[len(chain)-1] != root {
+		t.Errorf("expect the innermost error last, but got %v", chain[len(chain)-1])
+	}
+}
+
+func TestWalk_Stop(t *testing.T) {
+	err := fmt.Errorf("load user: %w", errors.NotFound)
+
+	var visits int
+	errors.Walk(err, func(e error) bool {
+		visits++
+		return false
+	})
+	if visits != 1 {
+		t.Errorf("expect Walk to stop after the first error, but got %d visits", visits)
+	}
+}
+
+func TestWalk_Failure(t *testing.T) {
+	// The cause sits behind the embedded error field of the failure
+	cause := errors.New("boom")
+	err := errors.WithNotFound(cause)
+
+	if root := errors.Root(err); root != cause {
+		t.Errorf("expect the embedded cause, but got %v", root)
+	}
+}
+
+func TestWalk_MultiFailure(t *testing.T) {
+	err := errors.Join(
+		errors.Bad(&errors.FieldViolation{Field: "email", Description: "missing"}),
+		errors.Aborted(),
+	)
+
+	// Walk follows a single chain, so it stops at the aggregate rather than
+	// picking one of its members
+	var chain []error
+	errors.Walk(err, func(e error) bool {
+		chain = append(chain, e)
+		return true
+	})
+	if len(chain) != 1 || chain[0] != err {
+		t.Errorf("expect Walk to visit the aggregate only, but got %v", chain)
+	}
+	if root := errors.Root(err); root != err {
+		t.Errorf("expect the aggregate as root, but got %v", root)
+	}
+}
+
+func TestRoot_Nil(t *testing.T) {
+	if root := errors.Root(nil); root != nil {
+		t.Errorf("expect nil, but got %v", root)
+	}
+}
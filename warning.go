@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// Warning reports a non-fatal, partial-degradation condition alongside a
+// successful response, e.g. a stale cache fallback or a quota close to
+// exhaustion. Warnings never fail the call; transports emit them out of
+// band (gRPC trailers, an HTTP header) next to the regular response.
+type Warning struct {
+	// Code is the canonical category of the degradation
+	Code Code `json:"code"`
+	// Message describes the condition
+	Message string `json:"message"`
+	// Violations lists the affected subjects
+	Violations []*WarningViolation `json:"violations,omitempty"`
+}
+
+// WarningViolation describes a single subject affected by a warning
+type WarningViolation struct {
+	// Subject is the resource, field or quota affected,
+	// e.g. "project:x/read" or "recommendations"
+	Subject string `json:"subject,omitempty"`
+	// Description tells the client what degraded and what to expect
+	Description string `json:"description,omitempty"`
+}
+
+type warningContextKey struct{}
+
+// warningCollector accumulates the warnings raised while serving a request.
+// It is shared by every context derived from the one it was installed on,
+// so handlers and the code they call see the same collection.
+type warningCollector struct {
+	mu       sync.Mutex
+	warnings []*Warning
+}
+
+// CollectWarnings returns a context that collects the warnings raised with
+// Warn, so a transport middleware can emit them when the response is
+// written
+func CollectWarnings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, warningContextKey{}, &warningCollector{})
+}
+
+// Warn records a warning on the collector carried by ctx. It is a no-op
+// when no collector is installed (see CollectWarnings), so libraries can
+// warn unconditionally.
+func Warn(ctx context.Context, w *Warning) {
+	c, ok := ctx.Value(warningContextKey{}).(*warningCollector)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	c.warnings = append(c.warnings, w)
+	c.mu.Unlock()
+}
+
+// Warnings returns the warnings collected on ctx so far
+func Warnings(ctx context.Context) []*Warning {
+	c, ok := ctx.Value(warningContextKey{}).(*warningCollector)
+	if !ok {
+		return nil
+	}
+	c.mu.Lock()
+	warnings := append([]*Warning(nil), c.warnings...)
+	c.mu.Unlock()
+	return warnings
+}
@@ -0,0 +1,50 @@
+package errors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deixis/errors"
+)
+
+func TestWarnings(t *testing.T) {
+	ctx := errors.CollectWarnings(context.Background())
+
+	errors.Warn(ctx, &errors.Warning{
+		Code:    errors.CodeResourceExhausted,
+		Message: "Read quota almost exhausted",
+		Violations: []*errors.WarningViolation{
+			{Subject: "project:x/read", Description: "90% of the daily limit used"},
+		},
+	})
+	errors.Warn(ctx, &errors.Warning{
+		Code:    errors.CodeUnavailable,
+		Message: "Recommendations degraded to popular items",
+	})
+
+	warnings := errors.Warnings(ctx)
+	if len(warnings) != 2 {
+		t.Fatalf("expect 2 warnings, but got %d", len(warnings))
+	}
+	if warnings[0].Violations[0].Subject != "project:x/read" {
+		t.Errorf("expect the quota violation, but got %v", warnings[0].Violations)
+	}
+}
+
+func TestWarnings_Derived(t *testing.T) {
+	ctx := errors.CollectWarnings(context.Background())
+	derived := context.WithValue(ctx, struct{}{}, "value")
+
+	errors.Warn(derived, &errors.Warning{Code: errors.CodeUnavailable})
+	if len(errors.Warnings(ctx)) != 1 {
+		t.Error("expect warnings raised on a derived context to be collected")
+	}
+}
+
+func TestWarnings_NoCollector(t *testing.T) {
+	ctx := context.Background()
+	errors.Warn(ctx, &errors.Warning{Code: errors.CodeUnavailable})
+	if warnings := errors.Warnings(ctx); warnings != nil {
+		t.Errorf("expect no warnings, but got %v", warnings)
+	}
+}